@@ -0,0 +1,125 @@
+// Package checkpoint menyimpan checkpoint backfill di file JSON lokal.
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// FileStore menyimpan semua unit backfill di satu file JSON. File ditulis ulang secara
+// atomik (file sementara lalu rename) setiap kali unit disimpan, sehingga checkpoint tetap
+// utuh walaupun proses berhenti di tengah jalan.
+type FileStore struct {
+	path string
+
+	mu    sync.Mutex
+	units map[string]domain.BackfillUnit
+}
+
+var _ repository.CheckpointStore = (*FileStore)(nil)
+
+// fileContent adalah isi file checkpoint.
+type fileContent struct {
+	Units []domain.BackfillUnit `json:"units"`
+}
+
+// NewFileStore membuat FileStore untuk path. File dibuat saat unit pertama disimpan.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load(ctx context.Context, source, query string) ([]domain.BackfillUnit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.read(); err != nil {
+		return nil, err
+	}
+
+	var units []domain.BackfillUnit
+	for _, unit := range s.units {
+		if unit.Source == source && unit.Query == query {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i].Day.Before(units[j].Day) })
+	return units, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, unit domain.BackfillUnit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.read(); err != nil {
+		return err
+	}
+	s.units[unit.Key()] = unit
+	return s.write()
+}
+
+// read memuat file sekali; file yang belum ada dianggap kosong.
+func (s *FileStore) read() error {
+	if s.units != nil {
+		return nil
+	}
+
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.units = make(map[string]domain.BackfillUnit)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read checkpoint %s: %w", s.path, err)
+	}
+
+	var content fileContent
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return fmt.Errorf("decode checkpoint %s: %w", s.path, err)
+	}
+	s.units = make(map[string]domain.BackfillUnit, len(content.Units))
+	for _, unit := range content.Units {
+		s.units[unit.Key()] = unit
+	}
+	return nil
+}
+
+func (s *FileStore) write() error {
+	content := fileContent{Units: make([]domain.BackfillUnit, 0, len(s.units))}
+	for _, unit := range s.units {
+		content.Units = append(content.Units, unit)
+	}
+	sort.Slice(content.Units, func(i, j int) bool { return content.Units[i].Key() < content.Units[j].Key() })
+
+	raw, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write checkpoint %s: %w", s.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint %s: %w", s.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint %s: %w", s.path, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write checkpoint %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -5,25 +5,89 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"the_scrapper/internal/adapter/extractor"
+	"the_scrapper/internal/adapter/fetch"
+	"the_scrapper/internal/dates"
 	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+const (
+	// Domain adalah domain situs, dipakai untuk konfigurasi limit per sumber di httpclient.
+	Domain = "detik.com"
+
+	// DefaultBaseURL adalah alamat halaman pencarian detik, bisa diganti dengan WithBaseURL.
+	DefaultBaseURL = "https://www.detik.com"
+
+	// DefaultMaxPages adalah batas halaman hasil pencarian per panggilan Search.
+	DefaultMaxPages = 20
+)
+
 type DetikScraper struct {
-	client *http.Client
+	client     *http.Client
+	baseURL    string
+	workers    int
+	maxPages   int
+	maxResults int
+}
+
+// Option mengatur perilaku DetikScraper.
+type Option func(*DetikScraper)
+
+// WithWorkers mengatur jumlah artikel yang diambil kontennya secara bersamaan.
+func WithWorkers(n int) Option {
+	return func(d *DetikScraper) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
 }
 
-func NewDetikScraper(client *http.Client) *DetikScraper {
-	return &DetikScraper{client: client}
+// WithMaxPages membatasi jumlah halaman hasil pencarian yang ditelusuri.
+func WithMaxPages(n int) Option {
+	return func(d *DetikScraper) {
+		if n > 0 {
+			d.maxPages = n
+		}
+	}
+}
+
+// WithMaxResults membatasi jumlah artikel unik yang dikembalikan (0 = tanpa batas).
+func WithMaxResults(n int) Option {
+	return func(d *DetikScraper) {
+		if n >= 0 {
+			d.maxResults = n
+		}
+	}
+}
+
+// WithBaseURL mengganti DefaultBaseURL, tanpa garis miring di akhir.
+func WithBaseURL(baseURL string) Option {
+	return func(d *DetikScraper) {
+		if baseURL != "" {
+			d.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+func NewDetikScraper(client *http.Client, opts ...Option) *DetikScraper {
+	d := &DetikScraper{client: client, baseURL: DefaultBaseURL, maxPages: DefaultMaxPages, workers: fetch.DefaultWorkers}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 func (d *DetikScraper) Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
-	fromStr := from.Format("03/01/2015")
-	toStr := to.Format("03/01/2015")
+	fromStr := dates.DetikQuery(from)
+	toStr := dates.DetikQuery(to)
 
 	params := url.Values{}
 	params.Set("query", query)
@@ -31,7 +95,81 @@ func (d *DetikScraper) Search(ctx context.Context, query string, from, to time.T
 	params.Set("todatex", toStr)
 	params.Set("result_type", "relevansi")
 
-	urlSearch := fmt.Sprintf("https://www.detik.com/search/searchall?%s", params.Encode())
+	var articles []domain.Article
+	seen := make(map[string]struct{})
+	limited := false
+	// pageErr adalah kegagalan halaman setelah halaman pertama; hasil halaman sebelumnya
+	// tetap dikembalikan bersamanya agar pemanggil mencatat pencarian ini sebagai gagal
+	var pageErr error
+
+	for page := 1; page <= d.maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageArticles, err := d.fetchSearchPage(ctx, params, page)
+		if err != nil {
+			if page == 1 {
+				return nil, err
+			}
+			pageErr = fmt.Errorf("search page %d: %w", page, err)
+			break
+		}
+
+		added := 0
+		for _, a := range pageArticles {
+			if _, ok := seen[a.URL]; ok {
+				continue
+			}
+			seen[a.URL] = struct{}{}
+			articles = append(articles, a)
+			added++
+		}
+		progress.Report(ctx, progress.Event{Stage: progress.StagePageFetched, Source: "detik", Page: page, Count: added})
+
+		// Halaman kosong atau hanya berisi duplikat berarti hasil sudah habis
+		if added == 0 {
+			break
+		}
+		if page == d.maxPages || d.reachedLimit(len(articles)) {
+			limited = true
+			break
+		}
+	}
+
+	if d.maxResults > 0 && len(articles) > d.maxResults {
+		articles = articles[:d.maxResults]
+	}
+
+	if limited {
+		progress.Report(ctx, progress.Event{Stage: progress.StageLimitReached, Source: "detik", Count: len(articles)})
+		// Pemanggil bisa menghentikan pencarian di sini, misal untuk memecah rentang tanggal
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range articles {
+		progress.Report(ctx, progress.Event{Stage: progress.StageArticleQueued, Source: "detik", URL: a.URL})
+	}
+
+	fetch.All(ctx, articles, d.workers, d.scrapeArticle)
+
+	return articles, pageErr
+}
+
+func (d *DetikScraper) reachedLimit(n int) bool {
+	return d.maxResults > 0 && n >= d.maxResults
+}
+
+func (d *DetikScraper) fetchSearchPage(ctx context.Context, params url.Values, page int) ([]domain.Article, error) {
+	pageParams := url.Values{}
+	for k, v := range params {
+		pageParams[k] = v
+	}
+	pageParams.Set("page", strconv.Itoa(page))
+
+	urlSearch := fmt.Sprintf("%s/search/searchall?%s", d.baseURL, pageParams.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlSearch, nil)
 	if err != nil {
@@ -63,52 +201,94 @@ func (d *DetikScraper) Search(ctx context.Context, query string, from, to time.T
 
 		if title != "" && link != "" {
 			articles = append(articles, domain.Article{
-				Title:   title,
-				URL:     link,
-				Summary: summary,
+				Title:    title,
+				URL:      link,
+				Summary:  summary,
+				Source:   "detik",
+				Language: "id",
 			})
 		}
 	})
 
-	for i, a := range articles {
-		content, err := d.scrapeArticleContent(ctx, a.URL)
-		if err != nil {
-			fmt.Printf("[warn] gagal ambil konten %s: %v\n", a.URL, err)
-			continue
-		}
-		articles[i].Content = content
-	}
-
 	return articles, nil
 }
 
-func (d *DetikScraper) scrapeArticleContent(ctx context.Context, articleURL string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, articleURL, nil)
+// FetchArticle mengambil ulang konten dan metadata artikel yang sudah tersimpan.
+func (d *DetikScraper) FetchArticle(ctx context.Context, article *domain.Article) error {
+	return d.scrapeArticle(ctx, article)
+}
+
+func (d *DetikScraper) scrapeArticle(ctx context.Context, article *domain.Article) error {
+	article.ScrapedAt = time.Now().UTC()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, article.URL, nil)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; DetikScraper/1.0)")
 
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("failed to fetch article: %d", resp.StatusCode)
+		return fmt.Errorf("failed to fetch article: %d", resp.StatusCode)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	// JSON-LD dan OpenGraph menjadi sumber utama, selector CSS hanya cadangan
+	extractor.Extract(doc).Apply(article)
+
+	if article.Content == "" {
+		article.Content = strings.TrimSpace(doc.Find("div.detail__body-text").Text())
+	}
+	if article.Content == "" {
+		article.Content = strings.TrimSpace(doc.Find("div.detail__body").Text())
+	}
+
+	if article.PublishedAt.IsZero() {
+		article.PublishedAt = dates.ParseOrZero(extractor.MetaContent(doc, "meta[name='publishdate']"))
+	}
+	if article.UpdatedAt.IsZero() {
+		article.UpdatedAt = dates.ParseOrZero(extractor.MetaContent(doc, "meta[name='updatedate']"))
+	}
+
+	if len(article.Authors) == 0 {
+		if author := extractor.MetaContent(doc, "meta[name='author']"); author != "" {
+			article.Authors = extractor.SplitList(author)
+		} else if author := strings.TrimSpace(doc.Find("div.detail__author").First().Text()); author != "" {
+			article.Authors = []string{author}
+		}
 	}
 
-	content := strings.TrimSpace(doc.Find("div.detail__body-text").Text())
-	if content == "" {
-		content = strings.TrimSpace(doc.Find("div.detail__body").Text())
+	if article.Section == "" {
+		article.Section = extractor.MetaContent(doc, "meta[name='dtk:namakanal']")
 	}
 
-	return content, nil
+	if len(article.Tags) == 0 {
+		if keywords := extractor.MetaContent(doc, "meta[name='keywords']"); keywords != "" {
+			article.Tags = extractor.SplitList(keywords)
+		} else {
+			doc.Find("div.detail__body-tag a").Each(func(i int, s *goquery.Selection) {
+				if tag := strings.TrimSpace(s.Text()); tag != "" {
+					article.Tags = append(article.Tags, tag)
+				}
+			})
+		}
+	}
+
+	doc.Find("div.detail__media-image img").Each(func(i int, s *goquery.Selection) {
+		if src, ok := s.Attr("src"); ok && src != "" && !slices.Contains(article.ImageURLs, src) {
+			article.ImageURLs = append(article.ImageURLs, src)
+		}
+	})
+
+	return nil
 }
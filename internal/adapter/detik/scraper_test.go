@@ -0,0 +1,291 @@
+package detik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
+	"the_scrapper/internal/testutil"
+)
+
+// articleHost adalah host artikel di fixture yang diganti dengan alamat httptest.Server.
+const articleHost = "https://news.detik.com"
+
+// articlePages memetakan path artikel di halaman pencarian ke fixture-nya.
+var articlePages = map[string]string{
+	"/berita/d-4843212/banjir-rendam-ratusan-rumah-di-jakarta-timur":  "article_jsonld.html",
+	"/berita/d-4843390/anies-tinjau-pengungsi-banjir-di-kampung-pulo": "article_css.html",
+	"/berita/d-4843501/banjir-surut-warga-kampung-pulo-bersih-bersih": "article_css.html",
+	"/berita/d-4843622/krl-bekasi-terganggu-banjir":                   "article_jsonld.html",
+}
+
+// searchFixtures memilih fixture detik: halaman pencarian kedua berisi dua artikel baru dan
+// satu duplikat halaman pertama, halaman berikutnya kosong.
+func searchFixtures(w http.ResponseWriter, r *http.Request) string {
+	switch {
+	case r.URL.Path == "/search/searchall" && r.URL.Query().Get("page") == "1":
+		return "search.html"
+	case r.URL.Path == "/search/searchall" && r.URL.Query().Get("page") == "2":
+		return "search_page2.html"
+	case r.URL.Path == "/search/searchall":
+		return "search_empty.html"
+	}
+	name, ok := articlePages[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+	}
+	return name
+}
+
+func newFixtureServer(t *testing.T) *httptest.Server {
+	return testutil.NewFixtureServer(t, articleHost, searchFixtures)
+}
+
+func TestSearch(t *testing.T) {
+	srv := newFixtureServer(t)
+	scraper := NewDetikScraper(srv.Client(), WithBaseURL(srv.URL))
+
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	articles, err := scraper.Search(context.Background(), "banjir jakarta", day, day)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	tests := []struct {
+		title   string
+		path    string
+		summary string
+		content string
+	}{
+		{
+			title:   "Banjir Rendam Ratusan Rumah di Jakarta Timur",
+			path:    "/berita/d-4843212/banjir-rendam-ratusan-rumah-di-jakarta-timur",
+			summary: "Hujan deras sejak malam tahun baru membuat ratusan rumah di Jakarta Timur terendam banjir.",
+			content: "Hujan deras sejak malam tahun baru membuat ratusan rumah di Jakarta Timur terendam banjir. Ketinggian air mencapai 1,5 meter di beberapa titik.",
+		},
+		{
+			title:   "Anies Tinjau Pengungsi Banjir di Kampung Pulo",
+			path:    "/berita/d-4843390/anies-tinjau-pengungsi-banjir-di-kampung-pulo",
+			summary: "Gubernur DKI Jakarta Anies Baswedan meninjau posko pengungsian di Kampung Pulo.",
+			content: "Gubernur DKI Jakarta Anies Baswedan meninjau posko pengungsian di Kampung Pulo.\nRatusan warga masih bertahan di posko hingga air surut.",
+		},
+		{
+			title:   "Banjir Surut, Warga Kampung Pulo Bersih-bersih",
+			path:    "/berita/d-4843501/banjir-surut-warga-kampung-pulo-bersih-bersih",
+			summary: "Warga Kampung Pulo mulai membersihkan rumah setelah banjir surut.",
+			content: "Gubernur DKI Jakarta Anies Baswedan meninjau posko pengungsian di Kampung Pulo.\nRatusan warga masih bertahan di posko hingga air surut.",
+		},
+		{
+			title:   "KRL Bekasi Terganggu Banjir",
+			path:    "/berita/d-4843622/krl-bekasi-terganggu-banjir",
+			summary: "Perjalanan KRL tujuan Bekasi tertahan karena rel terendam.",
+			content: "Hujan deras sejak malam tahun baru membuat ratusan rumah di Jakarta Timur terendam banjir. Ketinggian air mencapai 1,5 meter di beberapa titik.",
+		},
+	}
+
+	if len(articles) != len(tests) {
+		t.Fatalf("Search() returned %d articles, want %d", len(articles), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := articles[i]
+			if got.FetchError != "" {
+				t.Fatalf("FetchError = %q", got.FetchError)
+			}
+			if got.Title != tt.title {
+				t.Errorf("Title = %q, want %q", got.Title, tt.title)
+			}
+			if want := srv.URL + tt.path; got.URL != want {
+				t.Errorf("URL = %q, want %q", got.URL, want)
+			}
+			if got.Summary != tt.summary {
+				t.Errorf("Summary = %q, want %q", got.Summary, tt.summary)
+			}
+			if got.Content != tt.content {
+				t.Errorf("Content = %q, want %q", got.Content, tt.content)
+			}
+			if got.Source != "detik" {
+				t.Errorf("Source = %q, want detik", got.Source)
+			}
+		})
+	}
+}
+
+func TestSearchPaging(t *testing.T) {
+	srv := newFixtureServer(t)
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Halaman 1 dan 2 masing-masing berisi dua artikel baru; halaman 3 kosong
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    int
+		pages   int
+		limited bool
+	}{
+		{name: "all pages", want: 4, pages: 3},
+		{name: "max pages", opts: []Option{WithMaxPages(1)}, want: 2, pages: 1, limited: true},
+		{name: "max pages on the last page", opts: []Option{WithMaxPages(2)}, want: 4, pages: 2, limited: true},
+		{name: "max results", opts: []Option{WithMaxResults(3)}, want: 3, pages: 2, limited: true},
+		{name: "max results on the first page", opts: []Option{WithMaxResults(1)}, want: 1, pages: 1, limited: true},
+		{name: "max results above the total", opts: []Option{WithMaxResults(10)}, want: 4, pages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			pages, limited := 0, false
+			ctx := progress.WithReporter(context.Background(), func(e progress.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch e.Stage {
+				case progress.StagePageFetched:
+					pages++
+				case progress.StageLimitReached:
+					limited = true
+				}
+			})
+
+			scraper := NewDetikScraper(srv.Client(), append([]Option{WithBaseURL(srv.URL)}, tt.opts...)...)
+			articles, err := scraper.Search(ctx, "banjir jakarta", day, day)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(articles) != tt.want {
+				t.Errorf("Search() returned %d articles, want %d", len(articles), tt.want)
+			}
+			if pages != tt.pages {
+				t.Errorf("fetched %d search pages, want %d", pages, tt.pages)
+			}
+			if limited != tt.limited {
+				t.Errorf("limit reached = %v, want %v", limited, tt.limited)
+			}
+		})
+	}
+}
+
+func TestFetchArticle(t *testing.T) {
+	srv := newFixtureServer(t)
+	scraper := NewDetikScraper(srv.Client(), WithBaseURL(srv.URL))
+
+	tests := []struct {
+		name         string
+		path         string
+		canonicalURL string
+		publishedAt  time.Time
+		updatedAt    time.Time
+		authors      []string
+		section      string
+		tags         []string
+		wantErr      bool
+	}{
+		{
+			name:         "json-ld",
+			path:         "/berita/d-4843212/banjir-rendam-ratusan-rumah-di-jakarta-timur",
+			canonicalURL: "/berita/d-4843212/banjir-rendam-ratusan-rumah-di-jakarta-timur",
+			publishedAt:  time.Date(2020, 1, 1, 1, 15, 30, 0, time.UTC),
+			updatedAt:    time.Date(2020, 1, 1, 2, 2, 11, 0, time.UTC),
+			authors:      []string{"Farih Maulana Sidik"},
+			section:      "Berita",
+			tags:         []string{"banjir", "jakarta timur", "hujan"},
+		},
+		{
+			name:        "css fallback",
+			path:        "/berita/d-4843390/anies-tinjau-pengungsi-banjir-di-kampung-pulo",
+			publishedAt: time.Date(2020, 1, 1, 6, 40, 5, 0, time.UTC),
+			updatedAt:   time.Date(2020, 1, 1, 7, 1, 0, 0, time.UTC),
+			authors:     []string{"Rolando Fransiscus Sihombing"},
+			section:     "detikNews",
+			tags:        []string{"anies baswedan", "banjir", "kampung pulo"},
+		},
+		{
+			name:    "not found",
+			path:    "/berita/d-1/tidak-ada",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := domain.Article{URL: srv.URL + tt.path, Source: "detik"}
+			err := scraper.FetchArticle(context.Background(), &article)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchArticle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			wantCanonical := ""
+			if tt.canonicalURL != "" {
+				wantCanonical = srv.URL + tt.canonicalURL
+			}
+			if article.CanonicalURL != wantCanonical {
+				t.Errorf("CanonicalURL = %q, want %q", article.CanonicalURL, wantCanonical)
+			}
+			if !article.PublishedAt.Equal(tt.publishedAt) {
+				t.Errorf("PublishedAt = %v, want %v", article.PublishedAt, tt.publishedAt)
+			}
+			if !article.UpdatedAt.Equal(tt.updatedAt) {
+				t.Errorf("UpdatedAt = %v, want %v", article.UpdatedAt, tt.updatedAt)
+			}
+			if !slices.Equal(article.Authors, tt.authors) {
+				t.Errorf("Authors = %q, want %q", article.Authors, tt.authors)
+			}
+			if article.Section != tt.section {
+				t.Errorf("Section = %q, want %q", article.Section, tt.section)
+			}
+			if !slices.Equal(article.Tags, tt.tags) {
+				t.Errorf("Tags = %q, want %q", article.Tags, tt.tags)
+			}
+		})
+	}
+}
+
+func TestSearchFailedFirstPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	scraper := NewDetikScraper(srv.Client(), WithBaseURL(srv.URL))
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := scraper.Search(context.Background(), "banjir", day, day); err == nil {
+		t.Fatal("Search() error = nil, want error for 503 on the first page")
+	}
+}
+
+func TestSearchFailedLaterPage(t *testing.T) {
+	srv := testutil.NewFixtureServer(t, articleHost, func(w http.ResponseWriter, r *http.Request) string {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return ""
+		}
+		return searchFixtures(w, r)
+	})
+
+	scraper := NewDetikScraper(srv.Client(), WithBaseURL(srv.URL))
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	articles, err := scraper.Search(context.Background(), "banjir", day, day)
+	if err == nil {
+		t.Fatal("Search() error = nil, want error for 503 on the second page")
+	}
+	// Artikel halaman pertama tetap dikembalikan bersama error
+	if len(articles) != 2 {
+		t.Fatalf("Search() returned %d articles, want the 2 from the first page", len(articles))
+	}
+	for _, a := range articles {
+		if a.Content == "" {
+			t.Errorf("article %s was not fetched", a.URL)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+// csvHeader adalah kolom CSV, sama dengan tag json domain.Article.
+var csvHeader = []string{
+	"id", "source", "title", "url", "canonical_url", "summary", "content",
+	"published_at", "updated_at", "authors", "section", "tags", "language",
+	"image_urls", "scraped_at", "fetch_error", "fetch_path",
+}
+
+// listSeparator memisahkan nilai daftar (authors, tags, image_urls) dalam satu sel.
+const listSeparator = "; "
+
+// csvWriter menulis artikel sebagai CSV (RFC 4180). Sel yang berisi baris baru, koma
+// atau tanda kutip, misal content, otomatis diapit tanda kutip.
+type csvWriter struct {
+	cw *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return &csvWriter{cw: cw}, nil
+}
+
+func (w *csvWriter) Write(a domain.Article) error {
+	return w.cw.Write([]string{
+		a.ID, a.Source, a.Title, a.URL, a.CanonicalURL, a.Summary, a.Content,
+		formatTime(a.PublishedAt), formatTime(a.UpdatedAt),
+		strings.Join(a.Authors, listSeparator), a.Section, strings.Join(a.Tags, listSeparator), a.Language,
+		strings.Join(a.ImageURLs, listSeparator), formatTime(a.ScrapedAt), a.FetchError, a.FetchPath,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.cw.Flush()
+	return w.cw.Error()
+}
+
+// formatTime menulis waktu sebagai RFC 3339 UTC; waktu nol menjadi sel kosong.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	articles := []domain.Article{
+		{
+			ID:          "1",
+			Source:      "kompas",
+			Title:       "Banjir, \"Jakarta\"",
+			URL:         "https://example.com/banjir",
+			Content:     "Baris pertama\nBaris kedua",
+			PublishedAt: time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC),
+			Authors:     []string{"Penulis A", "Penulis B"},
+			FetchPath:   domain.FetchPathBrowser,
+		},
+		{ID: "2", Source: "detik", URL: "https://example.com/gagal", FetchError: "context deadline exceeded"},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, CSV, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range articles {
+		if err := w.Write(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read CSV: %v", err)
+	}
+	if len(records) != len(articles)+1 {
+		t.Fatalf("got %d records, want header and %d rows", len(records), len(articles))
+	}
+	column := make(map[string]int)
+	for i, name := range records[0] {
+		column[name] = i
+	}
+
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{1, "title", "Banjir, \"Jakarta\""},
+		{1, "content", "Baris pertama\nBaris kedua"},
+		{1, "published_at", "2020-01-01T03:00:00Z"},
+		{1, "authors", "Penulis A; Penulis B"},
+		{1, "fetch_error", ""},
+		{1, "fetch_path", "browser"},
+		{2, "fetch_error", "context deadline exceeded"},
+		{2, "fetch_path", ""},
+		{2, "published_at", ""},
+	}
+
+	for _, tt := range tests {
+		i, ok := column[tt.column]
+		if !ok {
+			t.Fatalf("header has no %q column: %q", tt.column, records[0])
+		}
+		if got := records[tt.row][i]; got != tt.want {
+			t.Errorf("row %d %s = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+}
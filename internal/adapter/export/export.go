@@ -0,0 +1,109 @@
+// Package export menulis artikel ke file datar: JSON Lines, CSV atau Parquet, dengan
+// kompresi gzip opsional. Artikel ditulis satu per satu sehingga pemakaian memori tidak
+// bergantung pada jumlah baris.
+package export
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"the_scrapper/internal/domain"
+)
+
+// Format adalah format file export.
+type Format string
+
+const (
+	JSONL   Format = "jsonl"
+	CSV     Format = "csv"
+	Parquet Format = "parquet"
+)
+
+// ParseFormat membaca nama format; string kosong berarti JSONL.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(name))); f {
+	case "", "json", "ndjson", JSONL:
+		return JSONL, nil
+	case CSV, Parquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q, use jsonl, csv or parquet", name)
+	}
+}
+
+// ContentType adalah MIME type file dengan format f.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case Parquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// Extension adalah ekstensi file untuk format f, termasuk ".gz" jika gzip.
+// Parquet memakai kompresi gzip internal, jadi tidak mendapat ".gz".
+func (f Format) Extension(gzipped bool) string {
+	ext := "." + string(f)
+	if gzipped && f != Parquet {
+		ext += ".gz"
+	}
+	return ext
+}
+
+// Writer menulis artikel satu per satu. Close wajib dipanggil untuk menulis sisa buffer
+// (dan footer Parquet); Close tidak menutup io.Writer tujuan.
+type Writer interface {
+	Write(article domain.Article) error
+	Close() error
+}
+
+// NewWriter membuat Writer untuk format ke w. Jika gzipped bernilai true, JSONL dan CSV
+// dibungkus gzip, sedangkan Parquet memakai kompresi GZIP per kolom.
+func NewWriter(w io.Writer, format Format, gzipped bool) (Writer, error) {
+	if format == Parquet {
+		return newParquetWriter(w, gzipped), nil
+	}
+
+	var gz *gzip.Writer
+	if gzipped {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+
+	var inner Writer
+	switch format {
+	case JSONL:
+		inner = newJSONLWriter(w)
+	case CSV:
+		cw, err := newCSVWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		inner = cw
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+
+	if gz == nil {
+		return inner, nil
+	}
+	return &gzipWriter{Writer: inner, gz: gz}, nil
+}
+
+// gzipWriter menutup writer format lalu gzip.
+type gzipWriter struct {
+	Writer
+	gz *gzip.Writer
+}
+
+func (w *gzipWriter) Close() error {
+	if err := w.Writer.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
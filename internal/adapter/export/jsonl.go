@@ -0,0 +1,28 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"the_scrapper/internal/domain"
+)
+
+// jsonlWriter menulis satu objek JSON artikel per baris.
+type jsonlWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonlWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (w *jsonlWriter) Write(article domain.Article) error {
+	return w.enc.Encode(article)
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.buf.Flush()
+}
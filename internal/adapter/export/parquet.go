@@ -0,0 +1,91 @@
+package export
+
+import (
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"the_scrapper/internal/domain"
+)
+
+// parquetRowGroupSize membatasi jumlah baris yang ditahan di memori sebelum row group
+// ditulis ke output.
+const parquetRowGroupSize = 10_000
+
+// parquetRow adalah skema Parquet untuk satu artikel. Waktu disimpan sebagai timestamp
+// milidetik UTC; waktu nol disimpan sebagai null.
+type parquetRow struct {
+	ID           string   `parquet:"id"`
+	Source       string   `parquet:"source,dict"`
+	Title        string   `parquet:"title"`
+	URL          string   `parquet:"url"`
+	CanonicalURL string   `parquet:"canonical_url"`
+	Summary      string   `parquet:"summary"`
+	Content      string   `parquet:"content"`
+	PublishedAt  int64    `parquet:"published_at,optional,timestamp(millisecond)"`
+	UpdatedAt    int64    `parquet:"updated_at,optional,timestamp(millisecond)"`
+	Authors      []string `parquet:"authors,list"`
+	Section      string   `parquet:"section,dict"`
+	Tags         []string `parquet:"tags,list"`
+	Language     string   `parquet:"language,dict"`
+	ImageURLs    []string `parquet:"image_urls,list"`
+	ScrapedAt    int64    `parquet:"scraped_at,optional,timestamp(millisecond)"`
+	FetchError   string   `parquet:"fetch_error"`
+	FetchPath    string   `parquet:"fetch_path,dict"`
+}
+
+// parquetWriter menulis artikel sebagai Parquet per row group.
+type parquetWriter struct {
+	pw   *parquet.GenericWriter[parquetRow]
+	rows []parquetRow
+}
+
+func newParquetWriter(w io.Writer, gzipped bool) *parquetWriter {
+	opts := []parquet.WriterOption{parquet.MaxRowsPerRowGroup(parquetRowGroupSize)}
+	if gzipped {
+		opts = append(opts, parquet.Compression(&parquet.Gzip))
+	} else {
+		opts = append(opts, parquet.Compression(&parquet.Snappy))
+	}
+	return &parquetWriter{
+		pw:   parquet.NewGenericWriter[parquetRow](w, opts...),
+		rows: make([]parquetRow, 0, 1),
+	}
+}
+
+func (w *parquetWriter) Write(a domain.Article) error {
+	w.rows = append(w.rows[:0], parquetRow{
+		ID:           a.ID,
+		Source:       a.Source,
+		Title:        a.Title,
+		URL:          a.URL,
+		CanonicalURL: a.CanonicalURL,
+		Summary:      a.Summary,
+		Content:      a.Content,
+		PublishedAt:  millis(a.PublishedAt),
+		UpdatedAt:    millis(a.UpdatedAt),
+		Authors:      a.Authors,
+		Section:      a.Section,
+		Tags:         a.Tags,
+		Language:     a.Language,
+		ImageURLs:    a.ImageURLs,
+		ScrapedAt:    millis(a.ScrapedAt),
+		FetchError:   a.FetchError,
+		FetchPath:    a.FetchPath,
+	})
+	_, err := w.pw.Write(w.rows)
+	return err
+}
+
+func (w *parquetWriter) Close() error {
+	return w.pw.Close()
+}
+
+// millis mengubah t menjadi milidetik Unix; waktu nol menjadi 0 yang ditulis sebagai null.
+func millis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,313 @@
+package extractor
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"the_scrapper/internal/dates"
+	"the_scrapper/internal/domain"
+)
+
+// Metadata adalah metadata artikel yang dibaca dari JSON-LD NewsArticle dan tag OpenGraph.
+type Metadata struct {
+	Title        string
+	Description  string
+	CanonicalURL string
+	Body         string
+	PublishedAt  time.Time
+	ModifiedAt   time.Time
+	Authors      []string
+	Keywords     []string
+	Section      string
+	Images       []string
+	Language     string
+}
+
+var articleTypes = []string{"NewsArticle", "Article", "ReportageNewsArticle", "AnalysisNewsArticle", "BlogPosting"}
+
+// Extract membaca JSON-LD terlebih dahulu, lalu melengkapi field yang kosong dari tag OpenGraph.
+func Extract(doc *goquery.Document) Metadata {
+	var m Metadata
+
+	doc.Find("script[type='application/ld+json']").Each(func(i int, s *goquery.Selection) {
+		for _, node := range findArticleNodes(decodeJSONLD(s.Text())) {
+			m.mergeJSONLD(node)
+		}
+	})
+
+	m.mergeOpenGraph(doc)
+
+	if href, ok := doc.Find("link[rel='canonical']").First().Attr("href"); ok {
+		setString(&m.CanonicalURL, strings.TrimSpace(href))
+	}
+	setString(&m.CanonicalURL, MetaContent(doc, "meta[property='og:url']"))
+
+	if m.Language == "" {
+		if lang, ok := doc.Find("html").Attr("lang"); ok {
+			m.Language = normalizeLanguage(lang)
+		}
+	}
+
+	return m
+}
+
+// Apply menyalin metadata yang terisi ke artikel. Judul dan ringkasan dari halaman
+// hasil pencarian dipertahankan jika sudah ada.
+func (m Metadata) Apply(article *domain.Article) {
+	if article.Title == "" {
+		article.Title = m.Title
+	}
+	if article.Summary == "" {
+		article.Summary = m.Description
+	}
+	if m.CanonicalURL != "" {
+		article.CanonicalURL = m.CanonicalURL
+	}
+	if m.Body != "" {
+		article.Content = m.Body
+	}
+	if !m.PublishedAt.IsZero() {
+		article.PublishedAt = m.PublishedAt
+	}
+	if !m.ModifiedAt.IsZero() {
+		article.UpdatedAt = m.ModifiedAt
+	}
+	if len(m.Authors) > 0 {
+		article.Authors = m.Authors
+	}
+	if m.Section != "" {
+		article.Section = m.Section
+	}
+	if len(m.Keywords) > 0 {
+		article.Tags = m.Keywords
+	}
+	if m.Language != "" {
+		article.Language = m.Language
+	}
+	if len(m.Images) > 0 {
+		article.ImageURLs = m.Images
+	}
+}
+
+func decodeJSONLD(raw string) any {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(raw), &data); err == nil {
+		return data
+	}
+
+	// Beberapa situs menaruh baris baru mentah di dalam string JSON-LD
+	cleaned := strings.NewReplacer("\r", " ", "\n", " ", "\t", " ").Replace(raw)
+	if err := json.Unmarshal([]byte(cleaned), &data); err == nil {
+		return data
+	}
+	return nil
+}
+
+// findArticleNodes mencari objek bertipe artikel, termasuk di dalam array dan @graph.
+func findArticleNodes(data any) []map[string]any {
+	var nodes []map[string]any
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			nodes = append(nodes, findArticleNodes(item)...)
+		}
+	case map[string]any:
+		if isArticleType(v["@type"]) {
+			nodes = append(nodes, v)
+		}
+		if graph, ok := v["@graph"]; ok {
+			nodes = append(nodes, findArticleNodes(graph)...)
+		}
+	}
+	return nodes
+}
+
+func isArticleType(t any) bool {
+	for _, name := range stringValues(t) {
+		if slices.Contains(articleTypes, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Metadata) mergeJSONLD(node map[string]any) {
+	setString(&m.Title, firstString(node["headline"]))
+	setString(&m.Description, firstString(node["description"]))
+	setString(&m.Body, firstString(node["articleBody"]))
+	setString(&m.Section, firstString(node["articleSection"]))
+	setString(&m.Language, normalizeLanguage(firstString(node["inLanguage"])))
+	setTime(&m.PublishedAt, firstString(node["datePublished"]))
+	setTime(&m.ModifiedAt, firstString(node["dateModified"]))
+
+	if len(m.Authors) == 0 {
+		m.Authors = names(node["author"])
+	}
+	if len(m.Keywords) == 0 {
+		m.Keywords = keywords(node["keywords"])
+	}
+	for _, image := range urls(node["image"]) {
+		m.Images = appendUnique(m.Images, image)
+	}
+}
+
+func (m *Metadata) mergeOpenGraph(doc *goquery.Document) {
+	setString(&m.Title, MetaContent(doc, "meta[property='og:title']"))
+	setString(&m.Description, MetaContent(doc, "meta[property='og:description']"))
+	setString(&m.Section, MetaContent(doc, "meta[property='article:section']"))
+	setString(&m.Language, normalizeLanguage(MetaContent(doc, "meta[property='og:locale']")))
+	setTime(&m.PublishedAt, MetaContent(doc, "meta[property='article:published_time']"))
+	setTime(&m.ModifiedAt, MetaContent(doc, "meta[property='article:modified_time']"))
+
+	if len(m.Authors) == 0 {
+		doc.Find("meta[property='article:author']").Each(func(i int, s *goquery.Selection) {
+			if author, _ := s.Attr("content"); strings.TrimSpace(author) != "" {
+				m.Authors = appendUnique(m.Authors, strings.TrimSpace(author))
+			}
+		})
+	}
+	if len(m.Keywords) == 0 {
+		doc.Find("meta[property='article:tag']").Each(func(i int, s *goquery.Selection) {
+			if tag, _ := s.Attr("content"); strings.TrimSpace(tag) != "" {
+				m.Keywords = appendUnique(m.Keywords, strings.TrimSpace(tag))
+			}
+		})
+	}
+	doc.Find("meta[property='og:image']").Each(func(i int, s *goquery.Selection) {
+		if image, _ := s.Attr("content"); strings.TrimSpace(image) != "" {
+			m.Images = appendUnique(m.Images, strings.TrimSpace(image))
+		}
+	})
+}
+
+// names membaca author yang bisa berupa string, objek {"name": ...}, atau array keduanya.
+func names(v any) []string {
+	var result []string
+	switch t := v.(type) {
+	case string:
+		for _, name := range SplitList(t) {
+			result = appendUnique(result, name)
+		}
+	case map[string]any:
+		if name := firstString(t["name"]); name != "" {
+			result = appendUnique(result, name)
+		}
+	case []any:
+		for _, item := range t {
+			for _, name := range names(item) {
+				result = appendUnique(result, name)
+			}
+		}
+	}
+	return result
+}
+
+// keywords membaca keywords yang bisa berupa string dipisah koma atau array string.
+func keywords(v any) []string {
+	var result []string
+	for _, value := range stringValues(v) {
+		for _, keyword := range SplitList(value) {
+			result = appendUnique(result, keyword)
+		}
+	}
+	return result
+}
+
+// urls membaca image yang bisa berupa string, objek ImageObject, atau array keduanya.
+func urls(v any) []string {
+	var result []string
+	switch t := v.(type) {
+	case string:
+		if s := strings.TrimSpace(t); s != "" {
+			result = append(result, s)
+		}
+	case map[string]any:
+		if u := firstString(t["url"]); u != "" {
+			result = append(result, u)
+		}
+	case []any:
+		for _, item := range t {
+			result = append(result, urls(item)...)
+		}
+	}
+	return result
+}
+
+func stringValues(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var result []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func firstString(v any) string {
+	for _, s := range stringValues(v) {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func setString(dst *string, value string) {
+	if *dst == "" && value != "" {
+		*dst = value
+	}
+}
+
+func setTime(dst *time.Time, value string) {
+	if dst.IsZero() && value != "" {
+		*dst = dates.ParseOrZero(value)
+	}
+}
+
+func normalizeLanguage(value string) string {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, "_-"); i > 0 {
+		value = value[:i]
+	}
+	return strings.ToLower(value)
+}
+
+// MetaContent mengembalikan atribut content elemen pertama yang cocok dengan selector,
+// tanpa spasi di awal dan akhir.
+func MetaContent(doc *goquery.Document, selector string) string {
+	content, _ := doc.Find(selector).First().Attr("content")
+	return strings.TrimSpace(content)
+}
+
+// SplitList memecah daftar yang dipisah koma, misal meta keywords, dan membuang item kosong.
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func appendUnique(items []string, value string) []string {
+	if slices.Contains(items, value) {
+		return items
+	}
+	return append(items, value)
+}
@@ -0,0 +1,139 @@
+package extractor
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		title    string
+		body     string
+		authors  []string
+		keywords []string
+	}{
+		{
+			name: "@graph array",
+			head: `<script type="application/ld+json">{"@context": "https://schema.org", "@graph": [
+				{"@type": "WebSite", "name": "Detik"},
+				{"@type": "BreadcrumbList", "headline": "Bukan artikel"},
+				{"@type": "NewsArticle", "headline": "Banjir Jakarta", "articleBody": "Isi berita.",
+				 "author": {"@type": "Person", "name": "Ani"}, "keywords": "banjir, jakarta"}
+			]}</script>`,
+			title:    "Banjir Jakarta",
+			body:     "Isi berita.",
+			authors:  []string{"Ani"},
+			keywords: []string{"banjir", "jakarta"},
+		},
+		{
+			name: "top-level array and @type array",
+			head: `<script type="application/ld+json">[
+				{"@type": "Organization", "name": "Liputan6"},
+				{"@type": ["NewsArticle", "Article"], "headline": "Gempa Cianjur", "author": "Budi"}
+			]</script>`,
+			title:   "Gempa Cianjur",
+			authors: []string{"Budi"},
+		},
+		{
+			name:    "author string with several names",
+			head:    `<script type="application/ld+json">{"@type": "NewsArticle", "author": "Ani, Budi , "}</script>`,
+			authors: []string{"Ani", "Budi"},
+		},
+		{
+			name: "author objects and strings in an array",
+			head: `<script type="application/ld+json">{"@type": "NewsArticle", "author": [
+				{"@type": "Person", "name": "Ani"}, "Budi", {"@type": "Person", "name": "Ani"}, {"@type": "Person"}
+			]}</script>`,
+			authors: []string{"Ani", "Budi"},
+		},
+		{
+			name:     "keywords array",
+			head:     `<script type="application/ld+json">{"@type": "NewsArticle", "keywords": ["banjir", "jakarta, bekasi", "banjir", 7]}</script>`,
+			keywords: []string{"banjir", "jakarta", "bekasi"},
+		},
+		{
+			name:  "raw newline inside a JSON-LD string",
+			head:  "<script type=\"application/ld+json\">{\"@type\": \"NewsArticle\", \"headline\": \"Banjir\nJakarta\"}</script>",
+			title: "Banjir Jakarta",
+		},
+		{
+			name: "OpenGraph when JSON-LD has no article",
+			head: `<script type="application/ld+json">{"@type": "WebPage", "headline": "Bukan artikel"}</script>
+				<meta property="og:title" content=" Banjir Bekasi ">
+				<meta property="article:author" content="Ani">
+				<meta property="article:author" content="Budi">
+				<meta property="article:tag" content="banjir">
+				<meta property="article:tag" content="bekasi">`,
+			title:    "Banjir Bekasi",
+			authors:  []string{"Ani", "Budi"},
+			keywords: []string{"banjir", "bekasi"},
+		},
+		{
+			name: "JSON-LD takes precedence over OpenGraph",
+			head: `<script type="application/ld+json">{"@type": "NewsArticle", "headline": "Dari JSON-LD", "author": {"name": "Ani"}, "keywords": ["banjir"]}</script>
+				<meta property="og:title" content="Dari OpenGraph">
+				<meta property="article:author" content="Budi">
+				<meta property="article:tag" content="gempa">`,
+			title:    "Dari JSON-LD",
+			authors:  []string{"Ani"},
+			keywords: []string{"banjir"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Extract(document(t, tt.head))
+			if m.Title != tt.title {
+				t.Errorf("Title = %q, want %q", m.Title, tt.title)
+			}
+			if m.Body != tt.body {
+				t.Errorf("Body = %q, want %q", m.Body, tt.body)
+			}
+			if !slices.Equal(m.Authors, tt.authors) {
+				t.Errorf("Authors = %q, want %q", m.Authors, tt.authors)
+			}
+			if !slices.Equal(m.Keywords, tt.keywords) {
+				t.Errorf("Keywords = %q, want %q", m.Keywords, tt.keywords)
+			}
+		})
+	}
+}
+
+func TestExtractDatesAndImages(t *testing.T) {
+	m := Extract(document(t, `<script type="application/ld+json">{"@type": "NewsArticle",
+		"datePublished": "2020-01-01T08:55:42+07:00",
+		"image": [{"@type": "ImageObject", "url": "https://img.example/a.jpg"}, "https://img.example/b.jpg"],
+		"inLanguage": "id-ID"}</script>
+		<meta property="article:modified_time" content="2020-01-01T10:00:00+07:00">
+		<meta property="og:image" content="https://img.example/a.jpg">
+		<meta property="og:image" content="https://img.example/c.jpg">`))
+
+	wib := time.FixedZone("WIB", 7*3600)
+	if want := time.Date(2020, 1, 1, 8, 55, 42, 0, wib); !m.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", m.PublishedAt, want)
+	}
+	if want := time.Date(2020, 1, 1, 10, 0, 0, 0, wib); !m.ModifiedAt.Equal(want) {
+		t.Errorf("ModifiedAt = %v, want %v", m.ModifiedAt, want)
+	}
+	if want := []string{"https://img.example/a.jpg", "https://img.example/b.jpg", "https://img.example/c.jpg"}; !slices.Equal(m.Images, want) {
+		t.Errorf("Images = %q, want %q", m.Images, want)
+	}
+	if m.Language != "id" {
+		t.Errorf("Language = %q, want id", m.Language)
+	}
+}
+
+func document(t *testing.T, head string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<html><head>" + head + "</head><body></body></html>"))
+	if err != nil {
+		t.Fatalf("parse document: %v", err)
+	}
+	return doc
+}
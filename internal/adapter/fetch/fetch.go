@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"context"
+	"sync"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
+)
+
+// DefaultWorkers adalah jumlah worker default untuk mengambil konten artikel.
+const DefaultWorkers = 4
+
+// ArticleFunc mengambil konten dan metadata satu artikel secara in-place.
+type ArticleFunc func(ctx context.Context, article *domain.Article) error
+
+// All menjalankan fn untuk setiap artikel dengan paling banyak `workers` goroutine.
+// Urutan slice tidak berubah; error per artikel dicatat di Article.FetchError.
+// Jika ctx dibatalkan, artikel yang belum diproses ditandai dengan error ctx.
+// Setiap artikel yang selesai dilaporkan ke progress reporter di ctx.
+func All(ctx context.Context, articles []domain.Article, workers int, fn ArticleFunc) {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if workers > len(articles) {
+		workers = len(articles)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				article := &articles[i]
+				article.FetchError = ""
+				if err := ctx.Err(); err != nil {
+					article.FetchError = err.Error()
+					continue
+				}
+				if err := fn(ctx, article); err != nil {
+					article.FetchError = err.Error()
+				}
+				reportFetched(ctx, *article)
+			}
+		}()
+	}
+
+	for i := range articles {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func reportFetched(ctx context.Context, article domain.Article) {
+	e := progress.Event{Stage: progress.StageContentFetched, Source: article.Source, URL: article.URL, Article: &article}
+	if article.FetchError != "" {
+		e.Stage = progress.StageContentFailed
+		e.Error = article.FetchError
+	}
+	progress.Report(ctx, e)
+}
+
+// Failed menghitung artikel yang gagal diambil kontennya.
+func Failed(articles []domain.Article) int {
+	n := 0
+	for _, a := range articles {
+		if a.FetchError != "" {
+			n++
+		}
+	}
+	return n
+}
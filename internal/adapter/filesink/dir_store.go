@@ -0,0 +1,363 @@
+// Package filesink menyimpan artikel ke file JSON Lines sebagai pengganti MongoDB.
+package filesink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"the_scrapper/internal/dates"
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// undatedFile menampung artikel tanpa tanggal terbit.
+const undatedFile = "undated"
+
+// DirStore menyimpan artikel di "<dir>/<source>/<YYYY-MM-DD>.jsonl", satu file per sumber
+// per hari terbit (WIB). Seperti MongoDB, artikel di-upsert berdasarkan canonical URL:
+// file hari tersebut dibaca, digabung, lalu ditulis ulang secara atomik. Artikel yang
+// tanggal terbitnya berubah dipindah dari file lamanya, sehingga satu URL hanya ada di
+// satu file; scrape ulang yang gagal mengambil halaman artikel tidak memindahkannya.
+type DirStore struct {
+	dir string
+	mu  sync.Mutex
+
+	// paths memetakan canonical URL ke file per sumber, dibaca dari direktori sumber
+	// saat sumber tersebut pertama kali disimpan
+	paths map[string]map[string]string
+}
+
+var _ repository.ArticleStore = (*DirStore)(nil)
+
+// NewDirStore membuat DirStore di dir. Direktori dibuat saat artikel pertama disimpan.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir, paths: make(map[string]map[string]string)}
+}
+
+// Path mengembalikan file tempat artikel disimpan.
+func (s *DirStore) Path(source string, article domain.Article) string {
+	name := undatedFile
+	if !article.PublishedAt.IsZero() {
+		name = dates.InJakarta(article.PublishedAt).Format("2006-01-02")
+	}
+	return filepath.Join(s.dir, source, name+".jsonl")
+}
+
+func (s *DirStore) Save(ctx context.Context, source string, articles []domain.Article) (repository.SaveResult, error) {
+	var result repository.SaveResult
+
+	// Artikel dengan canonical URL sama dalam satu batch cukup ditulis sekali (yang terakhir menang)
+	order := make([]string, 0, len(articles))
+	byKey := make(map[string]domain.Article, len(articles))
+	for _, article := range articles {
+		key := articleKey(article)
+		if key == "" {
+			result.Invalid++
+			continue
+		}
+		if _, ok := byKey[key]; !ok {
+			order = append(order, key)
+		} else {
+			result.Unchanged++
+		}
+		article.CanonicalURL = key
+		byKey[key] = article
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.index(source)
+	if err != nil {
+		return result, err
+	}
+
+	// Versi tersimpan dibaca dulu agar scraped_at dan data hasil parsingnya terbawa
+	stored, err := readStored(index, order)
+	if err != nil {
+		return result, err
+	}
+
+	byPath := make(map[string][]domain.Article)
+	var paths []string
+	// movedFrom mengelompokkan artikel yang sudah tersimpan di file lain per file lamanya
+	movedFrom := make(map[string][]string)
+	var oldPaths []string
+	for _, key := range order {
+		article := byKey[key]
+		if previous, ok := stored[key]; ok {
+			article = reconcile(previous, article)
+		}
+		path := s.Path(source, article)
+		if _, ok := byPath[path]; !ok {
+			paths = append(paths, path)
+		}
+		byPath[path] = append(byPath[path], article)
+
+		if old, ok := index[key]; ok && old != path {
+			if _, ok := movedFrom[old]; !ok {
+				oldPaths = append(oldPaths, old)
+			}
+			movedFrom[old] = append(movedFrom[old], key)
+		}
+	}
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		res, err := s.merge(path, byPath[path], stored)
+		result.Inserted += res.Inserted
+		result.Updated += res.Updated
+		result.Unchanged += res.Unchanged
+		if err != nil {
+			return result, err
+		}
+		for _, article := range byPath[path] {
+			index[article.CanonicalURL] = path
+		}
+	}
+
+	// Artikel baru dihapus dari file lama setelah file barunya tertulis, sehingga kegagalan
+	// di tengah jalan paling buruk meninggalkan duplikat, bukan kehilangan artikel
+	for _, old := range oldPaths {
+		if err := removeFromFile(old, movedFrom[old]); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// index mengembalikan peta canonical URL ke file untuk source, dibangun dari semua file
+// di direktori sumber pada pemanggilan pertama. Harus dipanggil dengan s.mu terkunci.
+func (s *DirStore) index(source string) (map[string]string, error) {
+	if index, ok := s.paths[source]; ok {
+		return index, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.dir, source, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]string)
+	for _, path := range files {
+		articles, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, article := range articles {
+			index[article.CanonicalURL] = path
+		}
+	}
+	s.paths[source] = index
+	return index, nil
+}
+
+// readStored membaca versi tersimpan artikel di keys yang sudah ada di index, satu kali
+// baca per file.
+func readStored(index map[string]string, keys []string) (map[string]domain.Article, error) {
+	byPath := make(map[string][]string)
+	for _, key := range keys {
+		if path, ok := index[key]; ok {
+			byPath[path] = append(byPath[path], key)
+		}
+	}
+
+	stored := make(map[string]domain.Article)
+	for path, keys := range byPath {
+		existing, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, article := range existing {
+			if slices.Contains(keys, article.CanonicalURL) {
+				stored[article.CanonicalURL] = article
+			}
+		}
+	}
+	return stored, nil
+}
+
+// reconcile menggabungkan artikel hasil scrape ulang dengan versi tersimpannya mengikuti
+// aturan upsert MongoDB: jika FetchError terisi hanya field halaman pencarian yang
+// diperbarui, sehingga konten, tanggal, fetch_error dan file lamanya tetap; jika berhasil,
+// field hasil parsing yang kosong diisi dari versi tersimpan. scraped_at pertama selalu
+// dipertahankan agar artikel yang tidak berubah terhitung sebagai unchanged.
+func reconcile(previous, article domain.Article) domain.Article {
+	if article.FetchError != "" {
+		merged := previous
+		merged.Title, merged.URL, merged.CanonicalURL = article.Title, article.URL, article.CanonicalURL
+		merged.Summary, merged.Source, merged.Language = article.Summary, article.Source, article.Language
+		return merged
+	}
+
+	article.ScrapedAt = previous.ScrapedAt
+	if article.Content == "" {
+		article.Content = previous.Content
+	}
+	if article.PublishedAt.IsZero() {
+		article.PublishedAt = previous.PublishedAt
+	}
+	if article.UpdatedAt.IsZero() {
+		article.UpdatedAt = previous.UpdatedAt
+	}
+	if len(article.Authors) == 0 {
+		article.Authors = previous.Authors
+	}
+	if article.Section == "" {
+		article.Section = previous.Section
+	}
+	if len(article.Tags) == 0 {
+		article.Tags = previous.Tags
+	}
+	if len(article.ImageURLs) == 0 {
+		article.ImageURLs = previous.ImageURLs
+	}
+	if article.FetchPath == "" {
+		article.FetchPath = previous.FetchPath
+	}
+	return article
+}
+
+// merge meng-upsert artikel yang sudah digabung dengan versi tersimpannya ke satu file.
+// Artikel di stored yang belum ada di file ini pindah dari file lain dan dihitung sebagai
+// updated.
+func (s *DirStore) merge(path string, articles []domain.Article, stored map[string]domain.Article) (repository.SaveResult, error) {
+	var result repository.SaveResult
+
+	existing, err := readFile(path)
+	if err != nil {
+		return result, err
+	}
+	index := make(map[string]int, len(existing))
+	for i, article := range existing {
+		index[article.CanonicalURL] = i
+	}
+
+	for _, article := range articles {
+		key := article.CanonicalURL
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(existing)
+			existing = append(existing, article)
+			if _, ok := stored[key]; ok {
+				result.Updated++
+			} else {
+				result.Inserted++
+			}
+			continue
+		}
+
+		if sameArticle(existing[i], article) {
+			result.Unchanged++
+			continue
+		}
+		existing[i] = article
+		result.Updated++
+	}
+
+	if result.Inserted == 0 && result.Updated == 0 {
+		return result, nil
+	}
+	if err := writeFile(path, existing); err != nil {
+		return repository.SaveResult{}, err
+	}
+	return result, nil
+}
+
+// removeFromFile menghapus artikel dengan canonical URL di keys dari file. File yang
+// menjadi kosong dihapus.
+func removeFromFile(path string, keys []string) error {
+	existing, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	kept := slices.DeleteFunc(existing, func(article domain.Article) bool {
+		return slices.Contains(keys, article.CanonicalURL)
+	})
+	if len(kept) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+		return nil
+	}
+	return writeFile(path, kept)
+}
+
+func articleKey(article domain.Article) string {
+	if article.CanonicalURL != "" {
+		return domain.CanonicalizeURL(article.CanonicalURL)
+	}
+	return domain.CanonicalizeURL(article.URL)
+}
+
+func sameArticle(a, b domain.Article) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ra, rb)
+}
+
+// readFile membaca artikel dari file JSONL; file yang belum ada dianggap kosong.
+func readFile(path string) ([]domain.Article, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var articles []domain.Article
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var article domain.Article
+		if err := dec.Decode(&article); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", path, err)
+		}
+		articles = append(articles, article)
+	}
+	return articles, nil
+}
+
+// writeFile menulis ulang file JSONL lewat file sementara lalu rename.
+func writeFile(path string, articles []domain.Article) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	buf := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(buf)
+	for _, article := range articles {
+		if err := enc.Encode(article); err != nil {
+			tmp.Close()
+			return fmt.Errorf("encode article %s: %w", article.URL, err)
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
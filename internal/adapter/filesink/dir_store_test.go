@@ -0,0 +1,115 @@
+package filesink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+func TestDirStoreMovesArticleWhenDateChanges(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	scrapedAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewDirStore(dir)
+	article := domain.Article{
+		Title:       "Banjir Jakarta",
+		URL:         "https://news.detik.com/berita/d-1/banjir",
+		PublishedAt: time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC),
+		ScrapedAt:   scrapedAt,
+	}
+	if _, err := store.Save(ctx, "detik", []domain.Article{article}); err != nil {
+		t.Fatal(err)
+	}
+	oldPath := store.Path("detik", article)
+
+	// Store baru harus menemukan artikel lama dari file yang sudah ada
+	store = NewDirStore(dir)
+	article.PublishedAt = article.PublishedAt.AddDate(0, 0, 1)
+	article.ScrapedAt = scrapedAt.AddDate(0, 0, 1)
+	result, err := store.Save(ctx, "detik", []domain.Article{article, {Title: "Tanpa URL"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Inserted != 0 || result.Updated != 1 || result.Invalid != 1 {
+		t.Errorf("Save() = %+v, want 1 updated and 1 invalid", result)
+	}
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old day file %s still exists: %v", filepath.Base(oldPath), err)
+	}
+	saved, err := readFile(store.Path("detik", article))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("new day file has %d articles, want 1", len(saved))
+	}
+	if !saved[0].ScrapedAt.Equal(scrapedAt) {
+		t.Errorf("ScrapedAt = %v, want the first scrape %v", saved[0].ScrapedAt, scrapedAt)
+	}
+}
+
+func TestDirStoreKeepsContentOnFailedRescrape(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := NewDirStore(dir)
+	good := domain.Article{
+		Title:       "Banjir Jakarta",
+		URL:         "https://news.detik.com/berita/d-1/banjir",
+		Summary:     "Ringkasan",
+		Content:     "Isi artikel",
+		PublishedAt: time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC),
+		Authors:     []string{"Penulis"},
+		ScrapedAt:   time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		FetchPath:   domain.FetchPathHTTP,
+	}
+	if _, err := store.Save(ctx, "detik", []domain.Article{good}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Halaman artikel gagal diambil: tanpa konten dan tanggal terbit
+	failed := domain.Article{
+		Title:      "Banjir Jakarta (update)",
+		URL:        good.URL,
+		Summary:    good.Summary,
+		ScrapedAt:  good.ScrapedAt.AddDate(0, 0, 1),
+		FetchError: "context deadline exceeded",
+	}
+	result, err := store.Save(ctx, "detik", []domain.Article{failed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Save() = %+v, want 1 updated", result)
+	}
+
+	if _, err := os.Stat(store.Path("detik", failed)); !os.IsNotExist(err) {
+		t.Errorf("failed re-scrape was written to %s: %v", undatedFile+".jsonl", err)
+	}
+	saved, err := readFile(store.Path("detik", good))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("day file has %d articles, want 1", len(saved))
+	}
+	got := saved[0]
+	if got.Title != failed.Title {
+		t.Errorf("Title = %q, want the search result title %q", got.Title, failed.Title)
+	}
+	if got.Content != good.Content || !got.PublishedAt.Equal(good.PublishedAt) || got.FetchPath != good.FetchPath {
+		t.Errorf("stored content was overwritten: %+v", got)
+	}
+	if got.FetchError != "" {
+		t.Errorf("FetchError = %q, want the stored empty value", got.FetchError)
+	}
+	if !got.ScrapedAt.Equal(good.ScrapedAt) {
+		t.Errorf("ScrapedAt = %v, want the first scrape %v", got.ScrapedAt, good.ScrapedAt)
+	}
+}
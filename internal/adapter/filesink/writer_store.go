@@ -0,0 +1,44 @@
+package filesink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// WriterStore menulis setiap artikel sebagai satu baris JSON ke io.Writer, misal stdout.
+// Tidak ada deduplikasi antar batch, jadi semua artikel terhitung sebagai inserted.
+type WriterStore struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+var _ repository.ArticleStore = (*WriterStore)(nil)
+
+// NewWriterStore membuat WriterStore yang menulis ke w.
+func NewWriterStore(w io.Writer) *WriterStore {
+	return &WriterStore{enc: json.NewEncoder(w)}
+}
+
+func (s *WriterStore) Save(ctx context.Context, source string, articles []domain.Article) (repository.SaveResult, error) {
+	var result repository.SaveResult
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, article := range articles {
+		if article.CanonicalURL == "" {
+			article.CanonicalURL = domain.CanonicalizeURL(article.URL)
+		}
+		if err := s.enc.Encode(article); err != nil {
+			return result, fmt.Errorf("write article %s: %w", article.URL, err)
+		}
+		result.Inserted++
+	}
+	return result, nil
+}
@@ -0,0 +1,234 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CassetteMode menentukan apakah response HTTP direkam ke atau diputar ulang dari cassette.
+type CassetteMode string
+
+const (
+	CassetteOff    CassetteMode = ""
+	CassetteRecord CassetteMode = "record"
+	CassetteReplay CassetteMode = "replay"
+)
+
+// ParseCassetteMode membaca "record", "replay", atau "" dan "off" untuk mematikan cassette.
+func ParseCassetteMode(value string) (CassetteMode, error) {
+	switch mode := CassetteMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case CassetteOff, "off":
+		return CassetteOff, nil
+	case CassetteRecord, CassetteReplay:
+		return mode, nil
+	default:
+		return CassetteOff, fmt.Errorf("invalid cassette mode %q: use record, replay or off", value)
+	}
+}
+
+// ErrCassetteMiss dikembalikan (terbungkus CassetteMissError) saat mode replay jika
+// request tidak ada di cassette.
+var ErrCassetteMiss = errors.New("request not found in cassette")
+
+// CassetteMissError menjelaskan request yang tidak ada di cassette.
+type CassetteMissError struct {
+	Method string
+	URL    string
+}
+
+func (e *CassetteMissError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrCassetteMiss, e.Method, e.URL)
+}
+
+func (e *CassetteMissError) Is(target error) bool {
+	return target == ErrCassetteMiss
+}
+
+// WithCassette merekam setiap pasangan request/response ke dir (CassetteRecord) atau
+// melayani response hanya dari dir tanpa akses jaringan (CassetteReplay).
+// Saat replay, rate limiter tidak dipakai karena tidak ada request ke situs asli.
+func WithCassette(dir string, mode CassetteMode) Option {
+	return func(c *config) {
+		c.cassetteDir = dir
+		c.cassetteMode = mode
+	}
+}
+
+// Replaying bernilai true jika client memutar ulang cassette. Request yang tidak lewat
+// client (misal navigasi chromedp) tidak boleh dilakukan karena tidak ada di cassette.
+func Replaying(client *http.Client) bool {
+	if client == nil {
+		return false
+	}
+	for rt := client.Transport; rt != nil; {
+		switch t := rt.(type) {
+		case *cassetteTransport:
+			return t.mode == CassetteReplay
+		case interface{ Unwrap() http.RoundTripper }:
+			rt = t.Unwrap()
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// cassetteEntry adalah satu response yang disimpan sebagai file JSON. Body disimpan
+// sebagai teks agar mudah dibaca, atau base64 jika bukan UTF-8 yang valid.
+type cassetteEntry struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	RecordedAt time.Time   `json:"recorded_at"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"body_base64,omitempty"`
+}
+
+// cassetteTransport merekam atau memutar ulang response berdasarkan method dan URL.
+// Request yang sama direkam ulang akan menimpa rekaman sebelumnya, misal saat retry.
+type cassetteTransport struct {
+	next http.RoundTripper
+	dir  string
+	mode CassetteMode
+}
+
+func newCassetteTransport(next http.RoundTripper, cfg *config) *cassetteTransport {
+	return &cassetteTransport{next: next, dir: cfg.cassetteDir, mode: cfg.cassetteMode}
+}
+
+func (t *cassetteTransport) Unwrap() http.RoundTripper {
+	return t.next
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == CassetteReplay {
+		return t.replay(req)
+	}
+	return t.record(req)
+}
+
+func (t *cassetteTransport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := cassetteEntry{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		RecordedAt: time.Now().UTC(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+	entry.Header.Del("Content-Length")
+	if utf8.Valid(body) {
+		entry.Body = string(body)
+	} else {
+		entry.BodyBase64 = body
+	}
+	if err := t.save(entry); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+func (t *cassetteTransport) replay(req *http.Request) (*http.Response, error) {
+	raw, err := os.ReadFile(t.path(req.Method, req.URL.String()))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &CassetteMissError{Method: req.Method, URL: req.URL.String()}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+
+	var entry cassetteEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("decode cassette %s %s: %w", req.Method, req.URL, err)
+	}
+
+	body := entry.BodyBase64
+	if body == nil {
+		body = []byte(entry.Body)
+	}
+	header := entry.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// path mengembalikan "<dir>/<host>/<METHOD>-<hash>.json" untuk method dan URL.
+func (t *cassetteTransport) path(method, rawURL string) string {
+	host := "unknown"
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = strings.ReplaceAll(strings.ToLower(u.Host), ":", "_")
+	}
+
+	sum := sha256.Sum256([]byte(method + " " + rawURL))
+	return filepath.Join(t.dir, host, method+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// save menulis entry secara atomik (file sementara lalu rename).
+func (t *cassetteTransport) save(entry cassetteEntry) error {
+	path := t.path(entry.Method, entry.URL)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create cassette directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette %s %s: %w", entry.Method, entry.URL, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cassette %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write cassette %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cassette %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write cassette %s: %w", path, err)
+	}
+	return nil
+}
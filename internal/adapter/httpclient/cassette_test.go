@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			http.NotFound(w, r)
+		case "/search":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, "<h3>Banjir "+r.URL.Query().Get("q")+"</h3>")
+		case "/image":
+			w.Write([]byte{0xff, 0xd8, 0xff, 0x00})
+		default:
+			http.Error(w, "gone", http.StatusGone)
+		}
+	}))
+
+	dir := t.TempDir()
+	recorder := NewHTTPClient(WithCassette(dir, CassetteRecord))
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/search?q=jakarta", http.StatusOK, "<h3>Banjir jakarta</h3>"},
+		{"/image", http.StatusOK, "\xff\xd8\xff\x00"},
+		{"/missing", http.StatusGone, "gone\n"},
+	}
+
+	for _, tt := range tests {
+		status, body := get(t, recorder, srv.URL+tt.path)
+		if status != tt.status || body != tt.body {
+			t.Fatalf("record %s = %d %q, want %d %q", tt.path, status, body, tt.status, tt.body)
+		}
+	}
+
+	// Setelah server mati, response hanya bisa datang dari cassette
+	srv.Close()
+	player := NewHTTPClient(WithCassette(dir, CassetteReplay))
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			status, body := get(t, player, srv.URL+tt.path)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if body != tt.body {
+				t.Errorf("body = %q, want %q", body, tt.body)
+			}
+		})
+	}
+
+	_, err := player.Get(srv.URL + "/search?q=bandung")
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("replay of unrecorded request error = %v, want ErrCassetteMiss", err)
+	}
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read %s: %v", url, err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestParseCassetteMode(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    CassetteMode
+		wantErr bool
+	}{
+		{"", CassetteOff, false},
+		{"off", CassetteOff, false},
+		{"record", CassetteRecord, false},
+		{" Replay ", CassetteReplay, false},
+		{"rewind", CassetteOff, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCassetteMode(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCassetteMode(%q) = %q, %v; want %q, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
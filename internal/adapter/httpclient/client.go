@@ -5,8 +5,79 @@ import (
 	"time"
 )
 
-func NewHTTPClient() *http.Client {
+// DefaultTimeout adalah batas waktu satu request setelah mendapat giliran dari rate limiter.
+const DefaultTimeout = 15 * time.Second
+
+type config struct {
+	timeout      time.Duration
+	defaultLimit Limit
+	hostLimits   map[string]Limit
+	retryPolicy  RetryPolicy
+	retryHook    func(RetryEvent)
+
+	robotsTTL       time.Duration
+	userAgent       string
+	robotsOverrides []string
+
+	cassetteDir  string
+	cassetteMode CassetteMode
+}
+
+// Option mengatur client yang dibuat NewHTTPClient.
+type Option func(*config)
+
+// WithTimeout mengatur batas waktu per request.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithDefaultLimit mengatur limit untuk host yang tidak punya limit khusus.
+func WithDefaultLimit(l Limit) Option {
+	return func(c *config) {
+		c.defaultLimit = l
+	}
+}
+
+// WithHostLimit mengatur limit untuk sebuah domain beserta subdomainnya,
+// contoh "detik.com" berlaku juga untuk "www.detik.com" dan "news.detik.com".
+func WithHostLimit(domain string, l Limit) Option {
+	return func(c *config) {
+		c.hostLimits[domain] = l
+	}
+}
+
+// NewHTTPClient membuat http.Client yang mematuhi robots.txt, membatasi laju dan jumlah
+// request bersamaan per host, serta mengulang request idempotent yang gagal sementara.
+// Setiap percobaan ulang kembali melewati rate limiter. Dengan WithCassette, response
+// direkam ke atau diputar ulang dari cassette di bawah lapisan-lapisan tersebut.
+func NewHTTPClient(opts ...Option) *http.Client {
+	cfg := &config{
+		timeout:      DefaultTimeout,
+		defaultLimit: DefaultLimit,
+		hostLimits:   make(map[string]Limit),
+		retryPolicy:  DefaultRetryPolicy,
+		robotsTTL:    DefaultRobotsTTL,
+		userAgent:    DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var transport http.RoundTripper
+	switch cfg.cassetteMode {
+	case CassetteRecord:
+		transport = newPoliteTransport(newCassetteTransport(http.DefaultTransport, cfg), cfg)
+	case CassetteReplay:
+		transport = newCassetteTransport(nil, cfg)
+	default:
+		transport = newPoliteTransport(http.DefaultTransport, cfg)
+	}
+
 	return &http.Client{
-		Timeout: 15 * time.Second,
+		Transport: newRobotsTransport(newRetryTransport(transport, cfg), cfg),
 	}
 }
@@ -0,0 +1,289 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit mengatur kesopanan akses ke satu host: token bucket dengan laju
+// RequestsPerSecond dan kapasitas Burst, serta maksimal MaxConcurrent request bersamaan.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+	MaxConcurrent     int
+}
+
+// DefaultLimit dipakai untuk host yang tidak dikonfigurasi secara khusus.
+var DefaultLimit = Limit{RequestsPerSecond: 2, Burst: 2, MaxConcurrent: 4}
+
+// LimitFromEnv membaca <PREFIX>_RPS, <PREFIX>_BURST dan <PREFIX>_MAX_CONCURRENT,
+// memakai nilai fallback untuk variabel yang kosong atau tidak valid.
+func LimitFromEnv(prefix string, fallback Limit) Limit {
+	l := fallback
+	if v, err := strconv.ParseFloat(os.Getenv(prefix+"_RPS"), 64); err == nil && v > 0 {
+		l.RequestsPerSecond = v
+	}
+	if v, err := strconv.Atoi(os.Getenv(prefix + "_BURST")); err == nil && v > 0 {
+		l.Burst = v
+	}
+	if v, err := strconv.Atoi(os.Getenv(prefix + "_MAX_CONCURRENT")); err == nil && v > 0 {
+		l.MaxConcurrent = v
+	}
+	return l
+}
+
+// hostLimiter adalah token bucket dan semaphore untuk satu host.
+type hostLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	slots       chan struct{}
+}
+
+func newHostLimiter(l Limit) *hostLimiter {
+	if l.RequestsPerSecond <= 0 {
+		l.RequestsPerSecond = DefaultLimit.RequestsPerSecond
+	}
+	if l.Burst <= 0 {
+		l.Burst = 1
+	}
+	if l.MaxConcurrent <= 0 {
+		l.MaxConcurrent = 1
+	}
+	return &hostLimiter{
+		rate:   l.RequestsPerSecond,
+		burst:  float64(l.Burst),
+		tokens: float64(l.Burst),
+		last:   time.Now(),
+		slots:  make(chan struct{}, l.MaxConcurrent),
+	}
+}
+
+// acquire menunggu slot konkurensi lalu token. Fungsi release wajib dipanggil.
+func (h *hostLimiter) acquire(ctx context.Context) (func(), error) {
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { <-h.slots })
+	}
+
+	for {
+		wait := h.reserve()
+		if wait <= 0 {
+			return release, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			release()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// reserve mengambil satu token jika tersedia, atau mengembalikan lama waktu tunggu.
+func (h *hostLimiter) reserve() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(h.pausedUntil) {
+		return h.pausedUntil.Sub(now)
+	}
+
+	h.tokens += now.Sub(h.last).Seconds() * h.rate
+	if h.tokens > h.burst {
+		h.tokens = h.burst
+	}
+	h.last = now
+
+	if h.tokens >= 1 {
+		h.tokens--
+		return 0
+	}
+	return time.Duration((1 - h.tokens) / h.rate * float64(time.Second))
+}
+
+// pause menghentikan request ke host sampai waktu tertentu (Retry-After).
+func (h *hostLimiter) pause(until time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if until.After(h.pausedUntil) {
+		h.pausedUntil = until
+	}
+}
+
+// minInterval memastikan jarak antar request tidak kurang dari d (misal crawl-delay).
+func (h *hostLimiter) minInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if rate := 1 / d.Seconds(); rate < h.rate {
+		h.rate = rate
+		h.burst = 1
+		if h.tokens > 1 {
+			h.tokens = 1
+		}
+	}
+}
+
+// politeTransport menerapkan hostLimiter pada setiap request.
+type politeTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+
+	defaultLimit Limit
+	hostLimits   map[string]Limit
+
+	mu       sync.Mutex
+	limiters map[string]*hostLimiter
+}
+
+func newPoliteTransport(base http.RoundTripper, cfg *config) *politeTransport {
+	return &politeTransport{
+		base:         base,
+		timeout:      cfg.timeout,
+		defaultLimit: cfg.defaultLimit,
+		hostLimits:   cfg.hostLimits,
+		limiters:     make(map[string]*hostLimiter),
+	}
+}
+
+func (t *politeTransport) limiter(host string) *hostLimiter {
+	host = strings.ToLower(host)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if l, ok := t.limiters[host]; ok {
+		return l
+	}
+	l := newHostLimiter(t.limitFor(host))
+	t.limiters[host] = l
+	return l
+}
+
+// limitFor mencari limit domain yang paling spesifik untuk host.
+func (t *politeTransport) limitFor(host string) Limit {
+	best, bestLen := t.defaultLimit, -1
+	for domain, l := range t.hostLimits {
+		domain = strings.ToLower(domain)
+		if (host == domain || strings.HasSuffix(host, "."+domain)) && len(domain) > bestLen {
+			best, bestLen = l, len(domain)
+		}
+	}
+	return best
+}
+
+func (t *politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limiter(req.URL.Hostname())
+
+	release, err := limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		release()
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			limiter.pause(time.Now().Add(d))
+		}
+	}
+
+	// Slot dan timeout dilepas saat body ditutup, karena membaca body masih bagian dari request
+	resp.Body = &releaseBody{ReadCloser: resp.Body, done: func() {
+		cancel()
+		release()
+	}}
+	return resp, nil
+}
+
+// Acquire meminta giliran dari rate limiter client untuk URL yang tidak diambil
+// lewat client itu sendiri (misal navigasi chromedp). Jika client tidak memakai
+// politeTransport, Acquire langsung mengembalikan release kosong.
+func Acquire(ctx context.Context, client *http.Client, rawURL string) (func(), error) {
+	t := findPoliteTransport(client.Transport)
+	if t == nil {
+		return func() {}, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+	return t.limiter(u.Hostname()).acquire(ctx)
+}
+
+func findPoliteTransport(rt http.RoundTripper) *politeTransport {
+	for rt != nil {
+		switch t := rt.(type) {
+		case *politeTransport:
+			return t
+		case interface{ Unwrap() http.RoundTripper }:
+			rt = t.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// parseRetryAfter membaca header Retry-After dalam detik atau HTTP-date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
@@ -0,0 +1,181 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostLimiterRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    Limit
+		requests int
+		min      time.Duration
+	}{
+		{"burst is not delayed", Limit{RequestsPerSecond: 10, Burst: 4, MaxConcurrent: 4}, 4, 0},
+		{"requests after the burst wait for tokens", Limit{RequestsPerSecond: 20, Burst: 2, MaxConcurrent: 4}, 6, 200 * time.Millisecond},
+		{"zero burst allows one request at once", Limit{RequestsPerSecond: 50, MaxConcurrent: 4}, 3, 40 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := newHostLimiter(tt.limit)
+
+			start := time.Now()
+			for range tt.requests {
+				release, err := limiter.acquire(context.Background())
+				if err != nil {
+					t.Fatalf("acquire() error = %v", err)
+				}
+				release()
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.min {
+				t.Errorf("%d requests took %v, want at least %v", tt.requests, elapsed, tt.min)
+			}
+			if limit := tt.min + 500*time.Millisecond; elapsed > limit {
+				t.Errorf("%d requests took %v, want at most %v", tt.requests, elapsed, limit)
+			}
+		})
+	}
+}
+
+func TestHostLimiterCanceled(t *testing.T) {
+	limiter := newHostLimiter(Limit{RequestsPerSecond: 1000, Burst: 10, MaxConcurrent: 1})
+
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire() with a busy slot error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// Slot yang dilepas bisa langsung dipakai lagi; release kedua kali tidak berefek
+	release()
+	release()
+	next, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire() after release error = %v", err)
+	}
+	next()
+}
+
+func TestPoliteTransportConcurrency(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxConcurrent int
+	}{
+		{"one at a time", 1},
+		{"three at a time", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var active, peak atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := active.Add(1)
+				defer active.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				io.WriteString(w, "ok")
+			}))
+			defer srv.Close()
+
+			cfg := &config{
+				timeout:      time.Second,
+				defaultLimit: Limit{RequestsPerSecond: 1000, Burst: 100, MaxConcurrent: 100},
+				hostLimits:   map[string]Limit{"127.0.0.1": {RequestsPerSecond: 1000, Burst: 100, MaxConcurrent: tt.maxConcurrent}},
+			}
+			client := &http.Client{Transport: newPoliteTransport(http.DefaultTransport, cfg)}
+
+			var wg sync.WaitGroup
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp, err := client.Get(srv.URL)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}()
+			}
+			wg.Wait()
+
+			if got := int(peak.Load()); got > tt.maxConcurrent {
+				t.Errorf("peak concurrent requests = %d, want at most %d", got, tt.maxConcurrent)
+			}
+		})
+	}
+}
+
+func TestLimitFor(t *testing.T) {
+	detik := Limit{RequestsPerSecond: 1, Burst: 1, MaxConcurrent: 1}
+	news := Limit{RequestsPerSecond: 3, Burst: 3, MaxConcurrent: 3}
+	transport := newPoliteTransport(nil, &config{
+		defaultLimit: DefaultLimit,
+		hostLimits:   map[string]Limit{"detik.com": detik, "news.detik.com": news},
+	})
+
+	tests := []struct {
+		host string
+		want Limit
+	}{
+		{"detik.com", detik},
+		{"www.detik.com", detik},
+		{"news.detik.com", news},
+		{"sport.news.detik.com", news},
+		{"notdetik.com", DefaultLimit},
+		{"kompas.com", DefaultLimit},
+	}
+
+	for _, tt := range tests {
+		if got := transport.limitFor(tt.host); got != tt.want {
+			t.Errorf("limitFor(%q) = %+v, want %+v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{" 0 ", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
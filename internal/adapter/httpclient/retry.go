@@ -0,0 +1,197 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy mengatur retry dengan exponential backoff dan jitter.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy dipakai jika NewHTTPClient tidak diberi WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+// RetryEvent dikirim ke hook setiap kali sebuah request akan diulang.
+type RetryEvent struct {
+	Method     string
+	URL        string
+	Attempt    int
+	StatusCode int
+	Err        error
+	Delay      time.Duration
+}
+
+func (e RetryEvent) String() string {
+	reason := fmt.Sprintf("status %d", e.StatusCode)
+	if e.Err != nil {
+		reason = e.Err.Error()
+	}
+	return fmt.Sprintf("%s %s attempt %d failed (%s), retrying in %s", e.Method, e.URL, e.Attempt, reason, e.Delay.Round(time.Millisecond))
+}
+
+// WithRetryPolicy mengatur kebijakan retry. MaxAttempts 1 mematikan retry.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *config) {
+		c.retryPolicy = p
+	}
+}
+
+// WithRetryHook memanggil fn setiap kali request diulang, misal untuk logging.
+func WithRetryHook(fn func(RetryEvent)) Option {
+	return func(c *config) {
+		c.retryHook = fn
+	}
+}
+
+// retryTransport mengulang request idempotent yang gagal karena error sementara.
+type retryTransport struct {
+	next    http.RoundTripper
+	policy  RetryPolicy
+	hook    func(RetryEvent)
+	retries atomic.Int64
+}
+
+func newRetryTransport(next http.RoundTripper, cfg *config) *retryTransport {
+	policy := cfg.retryPolicy
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if policy.MaxDelay < policy.BaseDelay {
+		policy.MaxDelay = policy.BaseDelay
+	}
+	return &retryTransport{next: next, policy: policy, hook: cfg.retryHook}
+}
+
+func (t *retryTransport) Unwrap() http.RoundTripper {
+	return t.next
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && d > delay {
+				delay = d
+			}
+		}
+
+		// Jangan menunggu jika sisa waktu context tidak cukup untuk percobaan berikutnya
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return resp, err
+		}
+
+		event := RetryEvent{Method: req.Method, URL: req.URL.String(), Attempt: attempt, Err: err, Delay: delay}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		t.retries.Add(1)
+		if t.hook != nil {
+			t.hook(event)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// backoff menghitung jeda exponential dengan jitter di rentang [d/2, d].
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.policy.BaseDelay << (attempt - 1)
+	if d <= 0 || d > t.policy.MaxDelay {
+		d = t.policy.MaxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// shouldRetry menentukan apakah kegagalan bersifat sementara: timeout per percobaan,
+// koneksi terputus, status 5xx, atau 429. Pembatalan dari context pemanggil tidak diulang.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isTransientError(err)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+func isTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// RetryCount mengembalikan total retry yang sudah dilakukan client sejak dibuat.
+func RetryCount(client *http.Client) int64 {
+	for rt := client.Transport; rt != nil; {
+		switch t := rt.(type) {
+		case *retryTransport:
+			return t.retries.Load()
+		case interface{ Unwrap() http.RoundTripper }:
+			rt = t.Unwrap()
+		default:
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,175 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	transport := newRetryTransport(nil, &config{retryPolicy: RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+	}})
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second}, // dibatasi MaxDelay
+		{80, time.Second},
+	}
+
+	for _, tt := range tests {
+		// Jitter acak, jadi setiap attempt dicoba beberapa kali
+		for range 50 {
+			if got := transport.backoff(tt.attempt); got < tt.max/2 || got > tt.max {
+				t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+// bodyRecorder mencatat body setiap response agar test bisa memeriksa body yang ditutup.
+type bodyRecorder struct {
+	next http.RoundTripper
+
+	mu     sync.Mutex
+	bodies []*closeRecorder
+}
+
+type closeRecorder struct {
+	io.ReadCloser
+	closed atomic.Bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed.Store(true)
+	return c.ReadCloser.Close()
+}
+
+func (r *bodyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body := &closeRecorder{ReadCloser: resp.Body}
+	resp.Body = body
+
+	r.mu.Lock()
+	r.bodies = append(r.bodies, body)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		retryAfter string
+		timeout    time.Duration
+		wantCalls  int
+		wantStatus int
+	}{
+		{"retries 5xx until success", http.MethodGet, []int{503, 502, 200}, "", 0, 3, 200},
+		{"gives up after max attempts", http.MethodGet, []int{503}, "", 0, 4, 503},
+		{"does not retry client errors", http.MethodGet, []int{404, 200}, "", 0, 1, 404},
+		{"does not retry 501", http.MethodGet, []int{501, 200}, "", 0, 1, 501},
+		{"does not retry POST", http.MethodPost, []int{503, 200}, "", 0, 1, 503},
+		{"retries 429", http.MethodGet, []int{429, 200}, "", 0, 2, 200},
+		{"backoff fits before the deadline", http.MethodGet, []int{429, 200}, "", 300 * time.Millisecond, 2, 200},
+		{"Retry-After past the deadline stops retrying", http.MethodGet, []int{429, 200}, "1", 300 * time.Millisecond, 1, 429},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1))
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				if status != http.StatusOK && tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				io.WriteString(w, "attempt body")
+			}))
+			defer srv.Close()
+
+			recorder := &bodyRecorder{next: http.DefaultTransport}
+			transport := newRetryTransport(recorder, &config{retryPolicy: RetryPolicy{
+				MaxAttempts: 4,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    5 * time.Millisecond,
+			}})
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			req, err := http.NewRequestWithContext(ctx, tt.method, srv.URL, strings.NewReader(""))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := int(calls.Load()); got != tt.wantCalls {
+				t.Errorf("server got %d requests, want %d", got, tt.wantCalls)
+			}
+			if got := int(transport.retries.Load()); got != tt.wantCalls-1 {
+				t.Errorf("retries = %d, want %d", got, tt.wantCalls-1)
+			}
+
+			// Body percobaan yang diulang harus ditutup, body yang dikembalikan belum
+			for i, body := range recorder.bodies {
+				last := i == len(recorder.bodies)-1
+				if got := body.closed.Load(); got == last {
+					t.Errorf("body of attempt %d closed = %v, want %v", i+1, got, !last)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportStopsOnCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transport := newRetryTransport(http.DefaultTransport, &config{
+		retryPolicy: RetryPolicy{MaxAttempts: 4, BaseDelay: time.Hour, MaxDelay: time.Hour},
+		// Jeda pertama satu jam; pembatalan harus menghentikannya
+		retryHook: func(RetryEvent) { cancel() },
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.RoundTrip(req); err != context.Canceled {
+		t.Errorf("RoundTrip() error = %v, want context.Canceled", err)
+	}
+}
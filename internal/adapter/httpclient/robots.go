@@ -0,0 +1,436 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRobotsTTL adalah lama robots.txt disimpan di cache per host.
+	DefaultRobotsTTL = time.Hour
+
+	// DefaultUserAgent adalah product token yang dicocokkan dengan baris User-agent di
+	// robots.txt (RFC 9309) jika request tidak punya User-Agent, dan User-Agent saat
+	// mengambil robots.txt untuk request tersebut.
+	DefaultUserAgent = "TheScrapper"
+
+	// robotsErrorTTL dipakai saat robots.txt tidak bisa diambil karena error 5xx.
+	robotsErrorTTL = 5 * time.Minute
+
+	// maxRobotsRedirects adalah jumlah redirect berturut-turut yang diikuti saat mengambil
+	// robots.txt (RFC 9309 bagian 2.3.1.2).
+	maxRobotsRedirects = 5
+
+	maxRobotsSize = 512 << 10
+)
+
+// ErrDisallowedByRobots dikembalikan (terbungkus DisallowedError) jika URL dilarang robots.txt.
+var ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+// DisallowedError menjelaskan URL dan user agent yang ditolak robots.txt.
+type DisallowedError struct {
+	URL       string
+	UserAgent string
+}
+
+func (e *DisallowedError) Error() string {
+	return fmt.Sprintf("%s: %s (user agent %q)", ErrDisallowedByRobots, e.URL, e.UserAgent)
+}
+
+func (e *DisallowedError) Is(target error) bool {
+	return target == ErrDisallowedByRobots
+}
+
+// WithRobotsTTL mengatur lama cache robots.txt per host.
+func WithRobotsTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		if ttl > 0 {
+			c.robotsTTL = ttl
+		}
+	}
+}
+
+// WithUserAgent mengganti product token DefaultUserAgent untuk request tanpa User-Agent.
+func WithUserAgent(agent string) Option {
+	return func(c *config) {
+		if agent != "" {
+			c.userAgent = agent
+		}
+	}
+}
+
+// WithRobotsOverride melewati pemeriksaan robots.txt untuk domain (dan subdomainnya)
+// yang sudah memberi izin eksplisit.
+func WithRobotsOverride(domains ...string) Option {
+	return func(c *config) {
+		c.robotsOverrides = append(c.robotsOverrides, domains...)
+	}
+}
+
+// robotsRule adalah satu baris Allow/Disallow.
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// robotsGroup adalah aturan untuk satu atau beberapa user agent.
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robotsData adalah hasil parse robots.txt satu host.
+type robotsData struct {
+	groups      []*robotsGroup
+	disallowAll bool
+}
+
+// parseRobots mem-parse robots.txt. User agent berurutan membentuk satu grup.
+func parseRobots(r io.Reader) *robotsData {
+	data := &robotsData{}
+	var current *robotsGroup
+	lastWasAgent := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if current == nil || !lastWasAgent {
+				current = &robotsGroup{}
+				data.groups = append(data.groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			lastWasAgent = true
+			continue
+		case "allow", "disallow":
+			if current != nil && (value != "" || key == "allow") {
+				current.rules = append(current.rules, robotsRule{
+					allow:   key == "allow",
+					pattern: value,
+					re:      compileRobotsPattern(value),
+				})
+			}
+		case "crawl-delay":
+			if current != nil {
+				if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+					current.crawlDelay = time.Duration(secs * float64(time.Second))
+				}
+			}
+		}
+		lastWasAgent = false
+	}
+	return data
+}
+
+// group memilih grup untuk product token, atau grup "*" jika tidak ada yang cocok.
+// Sesuai RFC 9309 token dicocokkan utuh tanpa membedakan huruf besar dan kecil, bukan
+// dicari di dalam header User-Agent, dan beberapa grup yang cocok digabung.
+func (d *robotsData) group(token string) *robotsGroup {
+	token = strings.ToLower(token)
+	var matched, wildcard []*robotsGroup
+	for _, g := range d.groups {
+		switch {
+		case slices.Contains(g.agents, token):
+			matched = append(matched, g)
+		case slices.Contains(g.agents, "*"):
+			wildcard = append(wildcard, g)
+		}
+	}
+	if len(matched) == 0 {
+		matched = wildcard
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil
+	case 1:
+		return matched[0]
+	}
+	merged := &robotsGroup{}
+	for _, g := range matched {
+		merged.agents = append(merged.agents, g.agents...)
+		merged.rules = append(merged.rules, g.rules...)
+		merged.crawlDelay = max(merged.crawlDelay, g.crawlDelay)
+	}
+	return merged
+}
+
+// allowed memakai aturan pola terpanjang; jika sama panjang, Allow menang.
+func (d *robotsData) allowed(token, path string) bool {
+	if d.disallowAll {
+		return false
+	}
+	g := d.group(token)
+	if g == nil {
+		return true
+	}
+
+	allowed, matchLen := true, -1
+	for _, rule := range g.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		if n := len(rule.pattern); n > matchLen || (n == matchLen && rule.allow) {
+			allowed, matchLen = rule.allow, n
+		}
+	}
+	return allowed
+}
+
+func (d *robotsData) crawlDelay(token string) time.Duration {
+	if g := d.group(token); g != nil {
+		return g.crawlDelay
+	}
+	return 0
+}
+
+// compileRobotsPattern mengubah pola robots.txt menjadi regexp; mendukung wildcard "*"
+// dan penanda akhir "$".
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+type robotsEntry struct {
+	mu        sync.Mutex
+	data      *robotsData
+	expiresAt time.Time
+}
+
+// robotsTransport menolak request yang dilarang robots.txt dan menerapkan crawl-delay.
+type robotsTransport struct {
+	next      http.RoundTripper
+	ttl       time.Duration
+	userAgent string
+	overrides []string
+
+	mu      sync.Mutex
+	entries map[string]*robotsEntry
+}
+
+func newRobotsTransport(next http.RoundTripper, cfg *config) *robotsTransport {
+	return &robotsTransport{
+		next:      next,
+		ttl:       cfg.robotsTTL,
+		userAgent: cfg.userAgent,
+		overrides: cfg.robotsOverrides,
+		entries:   make(map[string]*robotsEntry),
+	}
+}
+
+func (t *robotsTransport) Unwrap() http.RoundTripper {
+	return t.next
+}
+
+func (t *robotsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path != "/robots.txt" {
+		if err := t.check(req.Context(), req.URL, req.Header.Get("User-Agent")); err != nil {
+			return nil, err
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// check mengembalikan DisallowedError jika URL dilarang untuk product token yang dikirim
+// di userAgent, atau t.userAgent jika userAgent kosong.
+func (t *robotsTransport) check(ctx context.Context, u *url.URL, userAgent string) error {
+	host := strings.ToLower(u.Hostname())
+	if t.overridden(host) {
+		return nil
+	}
+	token := productToken(userAgent)
+	if token == "" {
+		userAgent, token = t.userAgent, t.userAgent
+	}
+
+	data, err := t.robots(ctx, u, userAgent)
+	if err != nil {
+		return err
+	}
+
+	if delay := data.crawlDelay(token); delay > 0 {
+		if polite := findPoliteTransport(t.next); polite != nil {
+			polite.limiter(host).minInterval(delay)
+		}
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if !data.allowed(token, path) {
+		return &DisallowedError{URL: u.String(), UserAgent: token}
+	}
+	return nil
+}
+
+// productToken mengambil nama crawler dari header User-Agent, misal "DetikScraper" dari
+// "Mozilla/5.0 (compatible; DetikScraper/1.0)": produk terakhir yang bukan "Mozilla",
+// termasuk yang ada di dalam komentar. Mengembalikan string kosong jika tidak ada.
+func productToken(userAgent string) string {
+	var token string
+	fields := strings.FieldsFunc(userAgent, func(r rune) bool {
+		return r == ' ' || r == '(' || r == ')' || r == ';'
+	})
+	for _, field := range fields {
+		name, _, _ := strings.Cut(field, "/")
+		if name == "" || strings.EqualFold(name, "mozilla") || strings.EqualFold(name, "compatible") {
+			continue
+		}
+		if strings.Contains(field, "/") || token == "" {
+			token = name
+		}
+	}
+	return token
+}
+
+func (t *robotsTransport) overridden(host string) bool {
+	for _, domain := range t.overrides {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// robots mengambil robots.txt dari cache atau dari server jika cache kedaluwarsa.
+func (t *robotsTransport) robots(ctx context.Context, u *url.URL, userAgent string) (*robotsData, error) {
+	key := u.Scheme + "://" + strings.ToLower(u.Host)
+
+	t.mu.Lock()
+	entry, ok := t.entries[key]
+	if !ok {
+		entry = &robotsEntry{}
+		t.entries[key] = entry
+	}
+	t.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.data != nil && time.Now().Before(entry.expiresAt) {
+		return entry.data, nil
+	}
+
+	data, ttl, err := t.fetchRobots(ctx, key+"/robots.txt", userAgent)
+	if err != nil {
+		return nil, err
+	}
+	entry.data = data
+	entry.expiresAt = time.Now().Add(ttl)
+	return data, nil
+}
+
+// fetchRobots mengikuti RFC 9309: redirect diikuti sampai maxRobotsRedirects kali,
+// 4xx (dan redirect yang lebih panjang) berarti semua diizinkan, 5xx berarti semua dilarang.
+func (t *robotsTransport) fetchRobots(ctx context.Context, robotsURL, userAgent string) (*robotsData, time.Duration, error) {
+	target, err := url.Parse(robotsURL)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for redirects := 0; ; redirects++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		req.Header.Set("User-Agent", userAgent)
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, 0, fmt.Errorf("fetch robots.txt %s: %w", robotsURL, err)
+		}
+
+		location := resp.Header.Get("Location")
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" {
+			defer resp.Body.Close()
+			return t.robotsFromResponse(resp)
+		}
+		resp.Body.Close()
+
+		if redirects == maxRobotsRedirects {
+			return &robotsData{}, t.ttl, nil
+		}
+		next, err := target.Parse(location)
+		if err != nil {
+			return &robotsData{}, t.ttl, nil
+		}
+		target = next
+	}
+}
+
+// robotsFromResponse mengubah response robots.txt yang bukan redirect menjadi aturan
+// beserta lama cache-nya.
+func (t *robotsTransport) robotsFromResponse(resp *http.Response) (*robotsData, time.Duration, error) {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return parseRobots(io.LimitReader(resp.Body, maxRobotsSize)), t.ttl, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return &robotsData{disallowAll: true}, robotsErrorTTL, nil
+	default:
+		return &robotsData{}, t.ttl, nil
+	}
+}
+
+// CheckRobots memeriksa robots.txt untuk URL yang tidak diambil lewat client (misal
+// navigasi chromedp) dengan product token dari userAgent. Mengembalikan nil jika client
+// tidak memakai robotsTransport.
+func CheckRobots(ctx context.Context, client *http.Client, rawURL, userAgent string) error {
+	t := findRobotsTransport(client.Transport)
+	if t == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+	return t.check(ctx, u, userAgent)
+}
+
+func findRobotsTransport(rt http.RoundTripper) *robotsTransport {
+	for rt != nil {
+		switch t := rt.(type) {
+		case *robotsTransport:
+			return t
+		case interface{ Unwrap() http.RoundTripper }:
+			rt = t.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRobotsGroup(t *testing.T) {
+	const robots = `
+User-agent: *
+Disallow: /private
+Crawl-delay: 1
+
+User-agent: Mozilla
+Disallow: /
+
+User-agent: GoogleBot
+User-agent: TheScrapper-News
+Disallow: /news
+
+# Grup kedua untuk token yang sama digabung
+User-agent: thescrapper
+Disallow: /search
+Crawl-delay: 3
+
+User-agent: TheScrapper
+Disallow: /tag
+`
+	data := parseRobots(strings.NewReader(robots))
+
+	tests := []struct {
+		token   string
+		path    string
+		allowed bool
+		delay   time.Duration
+		name    string
+	}{
+		{"TheScrapper", "/search?q=banjir", false, 3 * time.Second, "first group for the token"},
+		{"TheScrapper", "/tag/banjir", false, 3 * time.Second, "second group for the token is merged"},
+		{"TheScrapper", "/news/1", true, 3 * time.Second, "longer token does not match"},
+		{"TheScrapper", "/private", true, 3 * time.Second, "wildcard group is not used when a group matches"},
+		{"thescrapper", "/search", false, 3 * time.Second, "matching ignores case"},
+		{"Mozilla/5.0 (compatible; KompasScraper/1.0)", "/read/1", true, time.Second, "full User-Agent does not match the mozilla group"},
+		{"Mozilla/5.0 (compatible; KompasScraper/1.0)", "/private", false, time.Second, "wildcard group"},
+		{"googlebot", "/news/1", false, 0, "group with several agents"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := data.allowed(tt.token, tt.path); got != tt.allowed {
+				t.Errorf("allowed(%q, %q) = %v, want %v", tt.token, tt.path, got, tt.allowed)
+			}
+			if got := data.crawlDelay(tt.token); got != tt.delay {
+				t.Errorf("crawlDelay(%q) = %v, want %v", tt.token, got, tt.delay)
+			}
+		})
+	}
+}
+
+func TestRobotsLongestMatch(t *testing.T) {
+	const robots = `
+User-agent: *
+Disallow: /read
+Allow: /read/2020
+Disallow: /read/2020/*/foto
+Allow: /page
+Disallow: /page
+Disallow: /*.pdf$
+Disallow: /search?
+`
+	data := parseRobots(strings.NewReader(robots))
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/", true},
+		{"/read", false},
+		{"/read/2019/01/01/banjir", false},
+		{"/read/2020/01/01/banjir", true},
+		{"/read/2020/01/01/foto/banjir", false},
+		{"/page/2", true}, // Allow menang jika pola sama panjang
+		{"/laporan.pdf", false},
+		{"/laporan.pdf?download=1", true},
+		{"/search", true},
+		{"/search?q=banjir", false},
+	}
+
+	for _, tt := range tests {
+		if got := data.allowed(DefaultUserAgent, tt.path); got != tt.allowed {
+			t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.allowed)
+		}
+	}
+}
+
+func TestProductToken(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (compatible; DetikScraper/1.0)", "DetikScraper"},
+		{"Mozilla/5.0 (compatible; KompasScraper/1.0)", "KompasScraper"},
+		{"TheScrapper/2.1", "TheScrapper"},
+		{"TheScrapper", "TheScrapper"},
+		{"Mozilla/5.0", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := productToken(tt.userAgent); got != tt.want {
+			t.Errorf("productToken(%q) = %q, want %q", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+func TestRobotsCheck(t *testing.T) {
+	const robots = `
+User-agent: DetikScraper
+Disallow: /search
+
+User-agent: *
+Disallow: /private
+`
+	// /robots.txt mengarah ke /hop/1 ... /hop/n sebelum sampai ke /real-robots.txt
+	newServer := func(hops int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/robots.txt" && hops > 0:
+				http.Redirect(w, r, "/hop/1", http.StatusMovedPermanently)
+			case strings.HasPrefix(r.URL.Path, "/hop/"):
+				var n int
+				fmt.Sscanf(r.URL.Path, "/hop/%d", &n)
+				if n < hops {
+					http.Redirect(w, r, fmt.Sprintf("/hop/%d", n+1), http.StatusFound)
+					return
+				}
+				http.Redirect(w, r, "/real-robots.txt", http.StatusFound)
+			case r.URL.Path == "/robots.txt" || r.URL.Path == "/real-robots.txt":
+				fmt.Fprint(w, robots)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	}
+
+	tests := []struct {
+		name      string
+		hops      int
+		userAgent string
+		path      string
+		allowed   bool
+	}{
+		{"token from the sent User-Agent", 0, "Mozilla/5.0 (compatible; DetikScraper/1.0)", "/search?q=banjir", false},
+		{"other token uses the wildcard group", 0, "Mozilla/5.0 (compatible; KompasScraper/1.0)", "/search?q=banjir", true},
+		{"empty User-Agent uses the default token", 0, "", "/private", false},
+		{"redirect is followed", 1, "", "/private", false},
+		{"five redirects are followed", 4, "Mozilla/5.0 (compatible; DetikScraper/1.0)", "/search", false},
+		{"more than five redirects allow everything", 5, "", "/private", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(tt.hops)
+			defer srv.Close()
+
+			transport := newRobotsTransport(http.DefaultTransport, &config{robotsTTL: time.Hour, userAgent: DefaultUserAgent})
+			u, err := url.Parse(srv.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = transport.check(context.Background(), u, tt.userAgent)
+			if allowed := !errors.Is(err, ErrDisallowedByRobots); allowed != tt.allowed {
+				t.Errorf("check(%q) error = %v, want allowed %v", tt.path, err, tt.allowed)
+			}
+			if err != nil && !errors.Is(err, ErrDisallowedByRobots) {
+				t.Errorf("check(%q) unexpected error = %v", tt.path, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package kompas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	// DefaultMaxTabs adalah jumlah tab yang boleh terbuka bersamaan di satu browser.
+	DefaultMaxTabs = 4
+
+	// DefaultMaxNavigations adalah jumlah navigasi sebelum sebuah tab ditutup dan diganti
+	// tab baru, agar memori halaman lama tidak menumpuk.
+	DefaultMaxNavigations = 50
+
+	// browserProbeTimeout adalah batas waktu memeriksa apakah browser masih hidup.
+	browserProbeTimeout = 5 * time.Second
+)
+
+// ErrPoolClosed dikembalikan Acquire setelah BrowserPool ditutup.
+var ErrPoolClosed = errors.New("browser pool is closed")
+
+// ErrBrowserNotFound dikembalikan jika executable browser tidak ditemukan.
+var ErrBrowserNotFound = errors.New("chrome-compatible browser not found")
+
+// browserExecutables adalah browser yang dicari di PATH, sesuai urutan prioritas, jika
+// WithExecPath tidak dipakai.
+var browserExecutables = []string{
+	"brave", "brave-browser",
+	"google-chrome", "google-chrome-stable",
+	"chromium", "chromium-browser",
+	"headless_shell", "headless-shell",
+}
+
+// BrowserPool menjalankan satu proses browser yang dipakai bersama dan membagikan tab
+// kepada pemanggil. Browser baru dijalankan saat tab pertama diminta, dan dijalankan
+// ulang jika mati (crash atau ditutup dari luar).
+type BrowserPool struct {
+	execPath       string
+	flags          []string
+	proxy          string
+	userDataDir    string
+	remoteURL      string
+	maxTabs        int
+	maxNavigations int
+	slots          chan struct{}
+
+	// launch dan openTab menjalankan browser dan membuka tab; test menggantinya agar
+	// tidak butuh browser sungguhan
+	launch  func() (*browserProcess, error)
+	openTab func(b *browserProcess) (context.Context, context.CancelFunc, error)
+
+	mu      sync.Mutex
+	current *browserProcess
+	idle    []*Tab
+	closed  bool
+}
+
+// PoolOption mengatur perilaku BrowserPool.
+type PoolOption func(*BrowserPool)
+
+// WithMaxTabs membatasi jumlah tab yang terbuka bersamaan.
+func WithMaxTabs(n int) PoolOption {
+	return func(p *BrowserPool) {
+		if n > 0 {
+			p.maxTabs = n
+		}
+	}
+}
+
+// WithMaxNavigations mengatur jumlah navigasi sebelum tab didaur ulang.
+func WithMaxNavigations(n int) PoolOption {
+	return func(p *BrowserPool) {
+		if n > 0 {
+			p.maxNavigations = n
+		}
+	}
+}
+
+// WithExecPath memakai executable browser tertentu alih-alih mencari di PATH.
+func WithExecPath(path string) PoolOption {
+	return func(p *BrowserPool) {
+		p.execPath = path
+	}
+}
+
+// WithBrowserFlags menambah flag command line browser dalam bentuk "name" atau
+// "name=value", dengan atau tanpa awalan "--". "name=false" menghapus flag bawaan,
+// misal "headless=false" untuk menampilkan jendela browser.
+func WithBrowserFlags(flags ...string) PoolOption {
+	return func(p *BrowserPool) {
+		p.flags = append(p.flags, flags...)
+	}
+}
+
+// WithProxy menjalankan browser dengan proxy, misal "http://127.0.0.1:8080" atau
+// "socks5://127.0.0.1:1080".
+func WithProxy(proxy string) PoolOption {
+	return func(p *BrowserPool) {
+		p.proxy = proxy
+	}
+}
+
+// WithUserDataDir memakai direktori profil browser tertentu, misal agar cookie tersimpan
+// antar run. Tanpa opsi ini browser memakai direktori sementara.
+func WithUserDataDir(dir string) PoolOption {
+	return func(p *BrowserPool) {
+		p.userDataDir = dir
+	}
+}
+
+// WithRemoteURL tersambung ke browser yang sudah berjalan lewat DevTools, misal container
+// headless-shell, alih-alih menjalankan browser sendiri. url berbentuk
+// "ws://127.0.0.1:9222" atau "ws://127.0.0.1:9222/devtools/browser/<id>". Opsi
+// executable, flag, proxy dan user data dir tidak berlaku untuk browser remote.
+func WithRemoteURL(url string) PoolOption {
+	return func(p *BrowserPool) {
+		p.remoteURL = url
+	}
+}
+
+// BrowserOptionsFromEnv membaca <PREFIX>_BROWSER_PATH, <PREFIX>_BROWSER_FLAGS (dipisah
+// spasi), <PREFIX>_BROWSER_PROXY, <PREFIX>_BROWSER_USER_DATA_DIR dan <PREFIX>_BROWSER_URL.
+// Variabel yang kosong tidak menghasilkan opsi.
+func BrowserOptionsFromEnv(prefix string) []PoolOption {
+	var opts []PoolOption
+	if v := os.Getenv(prefix + "_BROWSER_PATH"); v != "" {
+		opts = append(opts, WithExecPath(v))
+	}
+	if v := strings.Fields(os.Getenv(prefix + "_BROWSER_FLAGS")); len(v) > 0 {
+		opts = append(opts, WithBrowserFlags(v...))
+	}
+	if v := os.Getenv(prefix + "_BROWSER_PROXY"); v != "" {
+		opts = append(opts, WithProxy(v))
+	}
+	if v := os.Getenv(prefix + "_BROWSER_USER_DATA_DIR"); v != "" {
+		opts = append(opts, WithUserDataDir(v))
+	}
+	if v := os.Getenv(prefix + "_BROWSER_URL"); v != "" {
+		opts = append(opts, WithRemoteURL(v))
+	}
+	return opts
+}
+
+// NewBrowserPool membuat BrowserPool. Browser belum dijalankan sampai Acquire dipanggil;
+// pakai Check untuk memastikan browser tersedia saat aplikasi mulai.
+// Pemanggil wajib memanggil Close saat aplikasi berhenti.
+func NewBrowserPool(opts ...PoolOption) *BrowserPool {
+	p := &BrowserPool{
+		maxTabs:        DefaultMaxTabs,
+		maxNavigations: DefaultMaxNavigations,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.slots = make(chan struct{}, p.maxTabs)
+	p.launch = p.launchBrowser
+	p.openTab = openTab
+	return p
+}
+
+// Remote bernilai true jika pool tersambung ke browser remote.
+func (p *BrowserPool) Remote() bool {
+	return p.remoteURL != ""
+}
+
+// Check memastikan browser bisa dipakai: executable browser harus ditemukan, atau browser
+// remote harus bisa dihubungi. Browser lokal tidak dijalankan oleh Check.
+func (p *BrowserPool) Check() error {
+	if !p.Remote() {
+		_, err := p.executable()
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	_, err := p.browserLocked()
+	return err
+}
+
+// executable mengembalikan path browser dari WithExecPath atau browser pertama di PATH.
+func (p *BrowserPool) executable() (string, error) {
+	if p.execPath != "" {
+		path, err := exec.LookPath(p.execPath)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrBrowserNotFound, err)
+		}
+		return path, nil
+	}
+	if path := findFirstExecutable(browserExecutables...); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("%w: none of %s in PATH", ErrBrowserNotFound, strings.Join(browserExecutables, ", "))
+}
+
+func findFirstExecutable(executables ...string) string {
+	for _, executable := range executables {
+		path, err := exec.LookPath(executable)
+		if err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// allocator membuat context chromedp yang menjalankan browser lokal atau tersambung ke
+// browser remote.
+func (p *BrowserPool) allocator() (context.Context, context.CancelFunc, error) {
+	if p.Remote() {
+		ctx, cancel := chromedp.NewRemoteAllocator(context.Background(), p.remoteURL)
+		return ctx, cancel, nil
+	}
+
+	execPath, err := p.executable()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:], chromedp.ExecPath(execPath))
+	for _, flag := range p.flags {
+		name, value, ok := strings.Cut(strings.TrimLeft(flag, "-"), "=")
+		switch {
+		case !ok || value == "true":
+			opts = append(opts, chromedp.Flag(name, true))
+		case value == "false":
+			opts = append(opts, chromedp.Flag(name, false))
+		default:
+			opts = append(opts, chromedp.Flag(name, value))
+		}
+	}
+	if p.proxy != "" {
+		opts = append(opts, chromedp.ProxyServer(p.proxy))
+	}
+	if p.userDataDir != "" {
+		opts = append(opts, chromedp.UserDataDir(p.userDataDir))
+	}
+
+	ctx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	return ctx, cancel, nil
+}
+
+// browserProcess adalah satu proses browser beserta context chromedp-nya.
+type browserProcess struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	allocCancel context.CancelFunc
+	remote      bool
+
+	mu   sync.Mutex
+	dead bool
+}
+
+func (b *browserProcess) alive() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.dead && b.ctx.Err() == nil
+}
+
+// stop menutup browser dengan rapi lalu menghentikan prosesnya. Browser remote tidak
+// ditutup, hanya koneksinya yang diputus.
+func (b *browserProcess) stop() error {
+	b.mu.Lock()
+	b.dead = true
+	b.mu.Unlock()
+
+	if b.remote {
+		b.cancel()
+		b.allocCancel()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(b.ctx, browserProbeTimeout)
+	defer cancel()
+	err := chromedp.Cancel(ctx)
+	b.cancel()
+	b.allocCancel()
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
+
+// Tab adalah satu tab browser yang dipinjam dari BrowserPool. Tab hanya boleh dipakai
+// oleh satu goroutine dan wajib dikembalikan dengan Release.
+type Tab struct {
+	pool        *BrowserPool
+	browser     *browserProcess
+	ctx         context.Context
+	cancel      context.CancelFunc
+	navigations int
+	broken      bool
+	released    bool
+}
+
+// Acquire meminjam tab, menunggu jika sudah ada maxTabs tab yang dipakai.
+func (p *BrowserPool) Acquire(ctx context.Context) (*Tab, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tab, err := p.tab()
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+	return tab, nil
+}
+
+// tab mengambil tab menganggur dari browser yang hidup atau membuka tab baru.
+func (p *BrowserPool) tab() (*Tab, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	b, err := p.browserLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	for len(p.idle) > 0 {
+		tab := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if tab.browser == b {
+			tab.released = false
+			return tab, nil
+		}
+		tab.cancel()
+	}
+
+	tabCtx, cancel, err := p.openTab(b)
+	if err != nil {
+		p.checkLocked(b)
+		return nil, fmt.Errorf("open browser tab: %w", err)
+	}
+	return &Tab{pool: p, browser: b, ctx: tabCtx, cancel: cancel}, nil
+}
+
+// openTab membuka tab baru di browser b.
+func openTab(b *browserProcess) (context.Context, context.CancelFunc, error) {
+	// Run pertama harus memakai context tab itu sendiri, karena chromedp mengikat
+	// umur tab pada context tersebut
+	tabCtx, cancel := chromedp.NewContext(b.ctx)
+	if err := chromedp.Run(tabCtx); err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return tabCtx, cancel, nil
+}
+
+// browserLocked mengembalikan browser yang hidup, menjalankan browser baru jika perlu.
+func (p *BrowserPool) browserLocked() (*browserProcess, error) {
+	if p.current != nil && p.current.alive() {
+		return p.current, nil
+	}
+	if p.current != nil {
+		log.Println("⚠️  Browser kompas mati, menjalankan browser baru")
+		p.current.stop()
+		p.current = nil
+	}
+
+	b, err := p.launch()
+	if err != nil {
+		return nil, err
+	}
+	p.current = b
+	return b, nil
+}
+
+// launchBrowser menjalankan browser lokal atau tersambung ke browser remote.
+func (p *BrowserPool) launchBrowser() (*browserProcess, error) {
+	allocCtx, allocCancel, err := p.allocator()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(log.Printf))
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		if p.Remote() {
+			return nil, fmt.Errorf("connect to browser %s: %w", p.remoteURL, err)
+		}
+		return nil, fmt.Errorf("start browser: %w", err)
+	}
+
+	return &browserProcess{ctx: ctx, cancel: cancel, allocCancel: allocCancel, remote: p.Remote()}, nil
+}
+
+// check menandai browser mati jika tidak lagi menjawab perintah.
+func (p *BrowserPool) check(b *browserProcess) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checkLocked(b)
+}
+
+func (p *BrowserPool) checkLocked(b *browserProcess) {
+	if !b.alive() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(b.ctx, browserProbeTimeout)
+	defer cancel()
+	if _, err := chromedp.Targets(ctx); err != nil {
+		b.mu.Lock()
+		b.dead = true
+		b.mu.Unlock()
+	}
+}
+
+// Close menutup semua tab dan browser. Tab yang masih dipinjam ikut tertutup.
+func (p *BrowserPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	for _, tab := range p.idle {
+		tab.cancel()
+	}
+	p.idle = nil
+
+	if p.current == nil {
+		return nil
+	}
+	err := p.current.stop()
+	p.current = nil
+	if err != nil {
+		return fmt.Errorf("close browser: %w", err)
+	}
+	return nil
+}
+
+// Navigate membuka url lalu menjalankan actions. Setiap navigasi dihitung untuk
+// mendaur ulang tab setelah maxNavigations.
+func (t *Tab) Navigate(ctx context.Context, url string, actions ...chromedp.Action) error {
+	t.navigations++
+	return t.Run(ctx, append([]chromedp.Action{chromedp.Navigate(url)}, actions...)...)
+}
+
+// Run menjalankan actions di tab. Jika ctx dibatalkan, actions dihentikan tanpa menutup
+// tab; tab yang gagal tidak dipakai ulang.
+func (t *Tab) Run(ctx context.Context, actions ...chromedp.Action) error {
+	runCtx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	err := chromedp.Run(runCtx, actions...)
+	if err == nil {
+		return nil
+	}
+
+	t.broken = true
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	t.pool.check(t.browser)
+	return err
+}
+
+// Release mengembalikan tab ke pool. Tab ditutup jika rusak, sudah mencapai
+// maxNavigations, atau browsernya sudah diganti.
+func (t *Tab) Release() {
+	p := t.pool
+
+	p.mu.Lock()
+	if t.released {
+		p.mu.Unlock()
+		return
+	}
+	t.released = true
+
+	reuse := !p.closed && !t.broken && t.navigations < p.maxNavigations &&
+		t.browser == p.current && t.browser.alive()
+	if reuse {
+		p.idle = append(p.idle, t)
+	}
+	p.mu.Unlock()
+
+	if !reuse {
+		t.cancel()
+	}
+	<-p.slots
+}
@@ -0,0 +1,192 @@
+package kompas
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeBrowsers menggantikan browser sungguhan: setiap proses hanyalah context yang bisa
+// dimatikan, dan setiap tab adalah context turunannya.
+type fakeBrowsers struct {
+	launched []*browserProcess
+}
+
+func newFakePool(t *testing.T, opts ...PoolOption) (*BrowserPool, *fakeBrowsers) {
+	t.Helper()
+	p := NewBrowserPool(opts...)
+	f := &fakeBrowsers{}
+	p.launch = func() (*browserProcess, error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		// Seperti browser remote, stop hanya membatalkan context tanpa perintah ke browser
+		b := &browserProcess{ctx: ctx, cancel: cancel, allocCancel: func() {}, remote: true}
+		f.launched = append(f.launched, b)
+		return b, nil
+	}
+	p.openTab = func(b *browserProcess) (context.Context, context.CancelFunc, error) {
+		ctx, cancel := context.WithCancel(b.ctx)
+		return ctx, cancel, nil
+	}
+	t.Cleanup(func() { p.Close() })
+	return p, f
+}
+
+func TestBrowserOptionsFromEnv(t *testing.T) {
+	t.Setenv("TEST_BROWSER_PATH", "/opt/chrome/chrome")
+	t.Setenv("TEST_BROWSER_FLAGS", "--disable-gpu  headless=false")
+	t.Setenv("TEST_BROWSER_PROXY", "socks5://127.0.0.1:1080")
+	t.Setenv("TEST_BROWSER_USER_DATA_DIR", "/tmp/profile")
+	t.Setenv("TEST_BROWSER_URL", "ws://127.0.0.1:9222")
+
+	p := NewBrowserPool(BrowserOptionsFromEnv("TEST")...)
+	if p.execPath != "/opt/chrome/chrome" {
+		t.Errorf("execPath = %q, want /opt/chrome/chrome", p.execPath)
+	}
+	if want := []string{"--disable-gpu", "headless=false"}; !slices.Equal(p.flags, want) {
+		t.Errorf("flags = %q, want %q", p.flags, want)
+	}
+	if p.proxy != "socks5://127.0.0.1:1080" {
+		t.Errorf("proxy = %q, want socks5://127.0.0.1:1080", p.proxy)
+	}
+	if p.userDataDir != "/tmp/profile" {
+		t.Errorf("userDataDir = %q, want /tmp/profile", p.userDataDir)
+	}
+	if !p.Remote() {
+		t.Error("Remote() = false, want true")
+	}
+}
+
+func TestCheckMissingBrowser(t *testing.T) {
+	p := NewBrowserPool(WithExecPath(filepath.Join(t.TempDir(), "chrome")))
+	defer p.Close()
+
+	if err := p.Check(); !errors.Is(err, ErrBrowserNotFound) {
+		t.Errorf("Check() error = %v, want ErrBrowserNotFound", err)
+	}
+	if _, err := p.Acquire(t.Context()); !errors.Is(err, ErrBrowserNotFound) {
+		t.Errorf("Acquire() error = %v, want ErrBrowserNotFound", err)
+	}
+}
+
+func TestBrowserPoolAcquireWaitsForSlot(t *testing.T) {
+	p, _ := newFakePool(t, WithMaxTabs(1))
+
+	tab, err := p.Acquire(t.Context())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := p.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Acquire() with every slot taken error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// Acquire yang menunggu mendapat slot setelah tab dikembalikan
+	got := make(chan *Tab, 1)
+	go func() {
+		tab, err := p.Acquire(t.Context())
+		if err != nil {
+			t.Errorf("waiting Acquire() error = %v", err)
+		}
+		got <- tab
+	}()
+	tab.Release()
+
+	select {
+	case second := <-got:
+		if second != nil {
+			second.Release()
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiting Acquire() did not return after Release()")
+	}
+}
+
+func TestTabReleaseReuse(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(tab *Tab)
+		reuse   bool
+	}{
+		{name: "idle tab", prepare: func(tab *Tab) {}, reuse: true},
+		{name: "below max navigations", prepare: func(tab *Tab) { tab.navigations = 2 }, reuse: true},
+		{name: "max navigations", prepare: func(tab *Tab) { tab.navigations = 3 }, reuse: false},
+		{name: "broken tab", prepare: func(tab *Tab) { tab.broken = true }, reuse: false},
+		{name: "browser replaced", prepare: func(tab *Tab) { tab.browser.cancel() }, reuse: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newFakePool(t, WithMaxNavigations(3))
+
+			tab, err := p.Acquire(t.Context())
+			if err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+			tt.prepare(tab)
+			tab.Release()
+			tab.Release() // Release kedua tidak boleh mengembalikan slot dua kali
+
+			next, err := p.Acquire(t.Context())
+			if err != nil {
+				t.Fatalf("second Acquire() error = %v", err)
+			}
+			defer next.Release()
+
+			if reused := next == tab; reused != tt.reuse {
+				t.Errorf("tab reused = %v, want %v", reused, tt.reuse)
+			}
+			if !tt.reuse && tab.ctx.Err() == nil {
+				t.Error("tab that is not reused was not closed")
+			}
+			if len(p.slots) != 1 {
+				t.Errorf("%d slots taken, want 1", len(p.slots))
+			}
+		})
+	}
+}
+
+func TestBrowserPoolRestartsDeadBrowser(t *testing.T) {
+	p, f := newFakePool(t)
+
+	tab, err := p.Acquire(t.Context())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	tab.Release()
+
+	// Browser mati saat tab menganggur, misal crash atau ditutup dari luar
+	first := f.launched[0]
+	first.cancel()
+
+	next, err := p.Acquire(t.Context())
+	if err != nil {
+		t.Fatalf("Acquire() after crash error = %v", err)
+	}
+	defer next.Release()
+
+	if len(f.launched) != 2 {
+		t.Fatalf("%d browsers launched, want 2", len(f.launched))
+	}
+	if next.browser != f.launched[1] {
+		t.Error("tab does not belong to the new browser")
+	}
+	if next == tab {
+		t.Error("idle tab of the dead browser was reused")
+	}
+	if !first.dead {
+		t.Error("dead browser was not stopped")
+	}
+
+	p.Close()
+	if f.launched[1].alive() {
+		t.Error("Close() did not stop the browser")
+	}
+	if _, err := p.Acquire(t.Context()); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Acquire() after Close() error = %v, want ErrPoolClosed", err)
+	}
+}
@@ -0,0 +1,216 @@
+package kompas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"the_scrapper/internal/dates"
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
+)
+
+// SearchMode menentukan cara KompasScraper mencari artikel.
+type SearchMode string
+
+const (
+	// SearchCSE memakai pencarian Google CSE di search.kompas.com yang dirender di browser.
+	SearchCSE SearchMode = "cse"
+
+	// SearchIndex menelusuri halaman indeks harian indeks.kompas.com lewat HTTP lalu
+	// menyaring judul artikel dengan query, tanpa browser.
+	SearchIndex SearchMode = "index"
+)
+
+// ParseSearchMode membaca "cse" atau "index"; nilai kosong berarti SearchCSE.
+func ParseSearchMode(value string) (SearchMode, error) {
+	switch mode := SearchMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "", SearchCSE:
+		return SearchCSE, nil
+	case SearchIndex:
+		return mode, nil
+	default:
+		return SearchCSE, fmt.Errorf("invalid kompas search mode %q: use cse or index", value)
+	}
+}
+
+const (
+	// DefaultIndexURL adalah alamat indeks harian kompas, bisa diganti dengan WithIndexURL.
+	DefaultIndexURL = "https://indeks.kompas.com"
+
+	// DefaultMaxIndexPages adalah batas halaman indeks per hari. Indeks semua kanal bisa
+	// berisi ratusan artikel per hari.
+	DefaultMaxIndexPages = 50
+)
+
+// WithSearchMode memilih pencarian Google CSE (default) atau indeks harian.
+func WithSearchMode(mode SearchMode) Option {
+	return func(k *KompasScraper) {
+		if mode != "" {
+			k.mode = mode
+		}
+	}
+}
+
+// WithIndexURL mengganti DefaultIndexURL, tanpa garis miring di akhir.
+func WithIndexURL(indexURL string) Option {
+	return func(k *KompasScraper) {
+		if indexURL != "" {
+			k.indexURL = strings.TrimSuffix(indexURL, "/")
+		}
+	}
+}
+
+// WithMaxIndexPages membatasi jumlah halaman indeks yang ditelusuri per hari.
+func WithMaxIndexPages(n int) Option {
+	return func(k *KompasScraper) {
+		if n > 0 {
+			k.maxIndexPages = n
+		}
+	}
+}
+
+// searchIndex menelusuri indeks setiap hari dari from sampai to dan mengembalikan artikel
+// yang judulnya cocok dengan query. limited bernilai true jika ada hari yang berhenti
+// karena batas halaman. Hari yang halaman pertamanya gagal dilewati dan error-nya
+// dikembalikan bersama artikel dari hari lain; kegagalan di halaman berikutnya hanya
+// mengakhiri penelusuran hari itu.
+func (k *KompasScraper) searchIndex(ctx context.Context, query string, from, to time.Time) ([]domain.Article, bool, error) {
+	var articles []domain.Article
+	var errs []error
+	seen := make(map[string]struct{})
+	limited := false
+	page := 0
+
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for dayPage := 1; dayPage <= k.maxIndexPages; dayPage++ {
+			if err := ctx.Err(); err != nil {
+				return nil, false, err
+			}
+
+			pageArticles, err := k.fetchIndexPage(ctx, day, dayPage)
+			if err != nil {
+				if dayPage == 1 {
+					errs = append(errs, fmt.Errorf("index %s: %w", dates.KompasQuery(day), err))
+					break
+				}
+				log.Printf("[warn] kompas: failed to open index %s page %d: %v", dates.KompasQuery(day), dayPage, err)
+				break
+			}
+			page++
+
+			// Halaman yang hanya berisi artikel yang sudah terlihat berarti indeks hari itu habis
+			added, matched := 0, 0
+			for _, a := range pageArticles {
+				if _, ok := seen[a.URL]; ok {
+					continue
+				}
+				seen[a.URL] = struct{}{}
+				added++
+				if matchesQuery(query, a.Title) {
+					articles = append(articles, a)
+					matched++
+				}
+			}
+			progress.Report(ctx, progress.Event{Stage: progress.StagePageFetched, Source: "kompas", Page: page, Count: matched})
+
+			if added == 0 {
+				break
+			}
+			if dayPage == k.maxIndexPages {
+				limited = true
+			}
+		}
+	}
+
+	return articles, limited, errors.Join(errs...)
+}
+
+// fetchIndexPage mengambil satu halaman indeks untuk satu hari.
+func (k *KompasScraper) fetchIndexPage(ctx context.Context, day time.Time, page int) ([]domain.Article, error) {
+	params := url.Values{}
+	params.Set("site", "all")
+	params.Set("date", dates.KompasQuery(day))
+	params.Set("page", strconv.Itoa(page))
+
+	urlIndex := fmt.Sprintf("%s/?%s", k.indexURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlIndex, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to fetch: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseIndexPage(doc), nil
+}
+
+// parseIndexPage mengambil daftar artikel dari halaman indeks. Tampilan baru memakai
+// div.articleItem, tampilan lama memakai div.article__list.
+func parseIndexPage(doc *goquery.Document) []domain.Article {
+	var articles []domain.Article
+	add := func(title, link, section, date string) {
+		title = strings.TrimSpace(title)
+		link = strings.TrimSpace(link)
+		if title == "" || link == "" || isMediaURL(link) {
+			return
+		}
+		articles = append(articles, domain.Article{
+			Title:       title,
+			URL:         link,
+			Section:     strings.TrimSpace(section),
+			PublishedAt: dates.ParseOrZero(date),
+			Source:      "kompas",
+			Language:    "id",
+		})
+	}
+
+	doc.Find("div.articleItem").Each(func(i int, s *goquery.Selection) {
+		link, _ := s.Find("a.article-link").First().Attr("href")
+		add(s.Find(".articleTitle").First().Text(), link,
+			s.Find(".articlePost-subtitle").First().Text(), s.Find(".articlePost-date").First().Text())
+	})
+	doc.Find("div.article__list").Each(func(i int, s *goquery.Selection) {
+		titleEl := s.Find("a.article__link").First()
+		link, _ := titleEl.Attr("href")
+		add(titleEl.Text(), link,
+			s.Find(".article__subtitle").First().Text(), s.Find(".article__date").First().Text())
+	})
+
+	return articles
+}
+
+// matchesQuery bernilai true jika semua kata query ada di title, tanpa membedakan huruf
+// besar dan kecil. Query kosong cocok dengan semua artikel.
+func matchesQuery(query, title string) bool {
+	title = strings.ToLower(title)
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(title, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -2,41 +2,191 @@ package kompas
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
-	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
 
+	"the_scrapper/internal/adapter/extractor"
+	"the_scrapper/internal/adapter/fetch"
+	"the_scrapper/internal/adapter/httpclient"
+	"the_scrapper/internal/dates"
 	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
 )
 
+const (
+	// Domain adalah domain situs, dipakai untuk konfigurasi limit per sumber di httpclient.
+	Domain = "kompas.com"
+
+	// DefaultBaseURL adalah alamat halaman pencarian kompas, bisa diganti dengan WithBaseURL.
+	DefaultBaseURL = "https://search.kompas.com"
+
+	// DefaultMaxPages adalah batas halaman hasil Google CSE per panggilan Search.
+	DefaultMaxPages = 10
+
+	// DefaultWorkers lebih kecil dari sumber lain karena artikel yang tidak bisa diambil
+	// lewat HTTP memakai satu tab browser.
+	DefaultWorkers = 2
+
+	// userAgent dikirim pada request HTTP; product token-nya (KompasScraper) juga dipakai
+	// untuk memeriksa robots.txt navigasi browser.
+	userAgent = "Mozilla/5.0 (compatible; KompasScraper/1.0)"
+)
+
+// errBrowserReplay dikembalikan saat client memutar ulang cassette dan halaman harus
+// dibuka di browser, karena navigasi browser tidak lewat http.Client sehingga tidak ada
+// di cassette.
+var errBrowserReplay = errors.New("kompas pages are rendered in a browser and cannot be replayed from a cassette")
+
 type KompasScraper struct {
-	client *http.Client
+	client        *http.Client
+	browser       *BrowserPool
+	browserOpts   []PoolOption
+	ownsBrowser   bool
+	mode          SearchMode
+	baseURL       string
+	indexURL      string
+	workers       int
+	maxPages      int
+	maxIndexPages int
 }
 
-func NewKompasScraper(client *http.Client) *KompasScraper {
-	return &KompasScraper{client: client}
+// Option mengatur perilaku KompasScraper.
+type Option func(*KompasScraper)
+
+// WithWorkers mengatur jumlah artikel yang diambil kontennya secara bersamaan.
+func WithWorkers(n int) Option {
+	return func(k *KompasScraper) {
+		if n > 0 {
+			k.workers = n
+		}
+	}
 }
 
-func findFirstExecutable(executables ...string) string {
-	for _, executable := range executables {
-		path, err := exec.LookPath(executable)
-		if err == nil {
-			return path
+// WithMaxPages membatasi jumlah halaman hasil Google CSE yang ditelusuri.
+func WithMaxPages(n int) Option {
+	return func(k *KompasScraper) {
+		if n > 0 {
+			k.maxPages = n
 		}
 	}
-	return ""
+}
+
+// WithBaseURL mengganti DefaultBaseURL, tanpa garis miring di akhir.
+func WithBaseURL(baseURL string) Option {
+	return func(k *KompasScraper) {
+		if baseURL != "" {
+			k.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+// WithBrowserPool memakai pool browser milik pemanggil, misal untuk berbagi satu browser
+// dengan scraper lain. Pool tersebut tidak ditutup oleh Close.
+func WithBrowserPool(pool *BrowserPool) Option {
+	return func(k *KompasScraper) {
+		if pool != nil {
+			k.browser = pool
+		}
+	}
+}
+
+// WithBrowserOptions mengatur BrowserPool milik scraper, misal WithExecPath atau
+// WithRemoteURL. Diabaikan jika WithBrowserPool dipakai.
+func WithBrowserOptions(opts ...PoolOption) Option {
+	return func(k *KompasScraper) {
+		k.browserOpts = append(k.browserOpts, opts...)
+	}
+}
+
+// NewKompasScraper membuat KompasScraper. Tanpa WithBrowserPool, scraper memiliki
+// BrowserPool sendiri yang ditutup oleh Close.
+func NewKompasScraper(client *http.Client, opts ...Option) *KompasScraper {
+	k := &KompasScraper{
+		client:        client,
+		mode:          SearchCSE,
+		baseURL:       DefaultBaseURL,
+		indexURL:      DefaultIndexURL,
+		maxPages:      DefaultMaxPages,
+		maxIndexPages: DefaultMaxIndexPages,
+		workers:       DefaultWorkers,
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	if k.browser == nil {
+		k.browser = NewBrowserPool(k.browserOpts...)
+		k.ownsBrowser = true
+	}
+	return k
+}
+
+// CheckBrowser memastikan browser tersedia sebelum scraping dimulai, agar browser yang
+// tidak ditemukan terlihat saat aplikasi mulai. Saat replay cassette browser tidak dipakai,
+// dan mode SearchIndex hanya memakai browser sebagai cadangan pengambilan artikel.
+func (k *KompasScraper) CheckBrowser() error {
+	if httpclient.Replaying(k.client) || k.mode == SearchIndex {
+		return nil
+	}
+	return k.browser.Check()
+}
+
+// Close menutup browser milik scraper. Dipanggil saat aplikasi berhenti.
+func (k *KompasScraper) Close() error {
+	if !k.ownsBrowser {
+		return nil
+	}
+	return k.browser.Close()
 }
 
 func (k *KompasScraper) Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
-	fromStr := from.Format("2006-01-02")
-	toStr := to.Format("2006-01-02")
+	var articles []domain.Article
+	var limited bool
+	var err error
+	if k.mode == SearchIndex {
+		articles, limited, err = k.searchIndex(ctx, query, from, to)
+	} else {
+		articles, limited, err = k.searchCSE(ctx, query, from, to)
+	}
+	// Pencarian indeks bisa mengembalikan artikel dari hari yang berhasil bersama error
+	if err != nil && len(articles) == 0 {
+		return nil, err
+	}
+
+	if len(articles) == 0 {
+		log.Printf("[INFO] kompas: No articles found (%s search).", k.mode)
+		return []domain.Article{}, nil
+	}
+
+	if limited {
+		progress.Report(ctx, progress.Event{Stage: progress.StageLimitReached, Source: "kompas", Count: len(articles)})
+		// Pemanggil bisa menghentikan pencarian di sini, misal untuk memecah rentang tanggal
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range articles {
+		progress.Report(ctx, progress.Event{Stage: progress.StageArticleQueued, Source: "kompas", URL: a.URL})
+	}
+
+	fetch.All(ctx, articles, k.workers, k.scrapeArticle)
+
+	return articles, err
+}
+
+// searchCSE mencari lewat Google CSE di browser.
+func (k *KompasScraper) searchCSE(ctx context.Context, query string, from, to time.Time) ([]domain.Article, bool, error) {
+	fromStr := dates.KompasQuery(from)
+	toStr := dates.KompasQuery(to)
 
 	params := url.Values{}
 	params.Set("q", query)
@@ -44,41 +194,110 @@ func (k *KompasScraper) Search(ctx context.Context, query string, from, to time.
 	params.Set("start_date", fromStr)
 	params.Set("end_date", toStr)
 
-	urlSearch := fmt.Sprintf("https://search.kompas.com/search?%s", params.Encode())
-
-	opts := chromedp.DefaultExecAllocatorOptions[:]
-	opts = append(opts, chromedp.Flag("headless", true))
+	urlSearch := fmt.Sprintf("%s/search?%s", k.baseURL, params.Encode())
 
-	if execPath := findFirstExecutable("brave", "brave-browser", "chromium-browser"); execPath != "" {
-		opts = append(opts, chromedp.ExecPath(execPath))
+	if httpclient.Replaying(k.client) {
+		return nil, false, errBrowserReplay
 	}
 
-	allocCtx, cancel := chromedp.NewExecAllocator(ctx, opts...)
-	defer cancel()
+	return k.searchPages(ctx, urlSearch)
+}
 
-	taskCtx, cancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(log.Printf))
-	defer cancel()
+// searchPages membuka hasil Google CSE di satu tab dan mengikuti halaman berikutnya.
+// limited bernilai true jika halaman terakhir yang diizinkan masih berisi hasil baru.
+func (k *KompasScraper) searchPages(ctx context.Context, urlSearch string) ([]domain.Article, bool, error) {
+	if err := httpclient.CheckRobots(ctx, k.client, urlSearch, userAgent); err != nil {
+		return nil, false, err
+	}
+	release, err := httpclient.Acquire(ctx, k.client, urlSearch)
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
+
+	tab, err := k.browser.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tab.Release()
 
 	var htmlBody string
-	err := chromedp.Run(taskCtx,
-		chromedp.Navigate(urlSearch),
+	err = tab.Navigate(ctx, urlSearch,
 		chromedp.WaitVisible("div.gsc-webResult", chromedp.ByQuery),
 		chromedp.OuterHTML("body", &htmlBody),
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("chromedp failed to execute search task: %w", err)
+		return nil, false, fmt.Errorf("chromedp failed to execute search task: %w", err)
 	}
 
+	articles, err := parseSearchResults(htmlBody)
+	if err != nil {
+		return nil, false, err
+	}
+
+	seen := make(map[string]struct{}, len(articles))
+	for _, a := range articles {
+		seen[a.URL] = struct{}{}
+	}
+	progress.Report(ctx, progress.Event{Stage: progress.StagePageFetched, Source: "kompas", Page: 1, Count: len(articles)})
+	limited := k.maxPages == 1 && len(articles) > 0
+
+	// Halaman berikutnya dibuka dengan mengklik gsc-cursor-page pada tab yang sama
+	for page := 2; page <= k.maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		ok, err := gotoResultsPage(ctx, tab, page)
+		if err != nil {
+			log.Printf("[warn] kompas: failed to open results page %d: %v", page, err)
+			break
+		}
+		if !ok {
+			break
+		}
+
+		if err := tab.Run(ctx, chromedp.OuterHTML("body", &htmlBody)); err != nil {
+			log.Printf("[warn] kompas: failed to read results page %d: %v", page, err)
+			break
+		}
+
+		pageArticles, err := parseSearchResults(htmlBody)
+		if err != nil {
+			return nil, false, err
+		}
+
+		added := 0
+		for _, a := range pageArticles {
+			if _, ok := seen[a.URL]; ok {
+				continue
+			}
+			seen[a.URL] = struct{}{}
+			articles = append(articles, a)
+			added++
+		}
+		progress.Report(ctx, progress.Event{Stage: progress.StagePageFetched, Source: "kompas", Page: page, Count: added})
+		if added == 0 {
+			break
+		}
+		limited = page == k.maxPages
+	}
+
+	return articles, limited, nil
+}
+
+// parseSearchResults mengambil daftar artikel dari HTML hasil Google CSE.
+func parseSearchResults(htmlBody string) ([]domain.Article, error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlBody))
 	if err != nil {
 		return nil, err
 	}
 
+	// Kontainer hasil juga berkelas gsc-webResult, jadi hanya item gsc-result yang dibaca
 	var articles []domain.Article
-
-	doc.Find("div.gsc-webResult").Each(func(i int, s *goquery.Selection) {
-		titleEl := s.Find("a.gs-title")
+	doc.Find("div.gsc-webResult.gsc-result").Each(func(i int, s *goquery.Selection) {
+		titleEl := s.Find("a.gs-title").First()
 		title := strings.TrimSpace(titleEl.Text())
 		link, _ := titleEl.Attr("href")
 
@@ -86,87 +305,231 @@ func (k *KompasScraper) Search(ctx context.Context, query string, from, to time.
 
 		if title != "" && link != "" {
 			articles = append(articles, domain.Article{
-				Title:   title,
-				URL:     link,
-				Summary: summary,
+				Title:    title,
+				URL:      link,
+				Summary:  summary,
+				Source:   "kompas",
+				Language: "id",
 			})
 		}
 	})
 
-	if len(articles) == 0 {
-		log.Println("[INFO] kompas: No articles found after chromedp execution.")
-		return []domain.Article{}, nil
+	return articles, nil
+}
+
+// gotoResultsPage mengklik elemen gsc-cursor-page untuk halaman tertentu dan menunggu
+// hasilnya dirender. Mengembalikan false jika halaman tersebut tidak tersedia.
+func gotoResultsPage(ctx context.Context, tab *Tab, page int) (bool, error) {
+	clickJS := fmt.Sprintf(`(() => {
+		const el = Array.from(document.querySelectorAll("div.gsc-cursor-page"))
+			.find(e => e.textContent.trim() === "%d");
+		if (!el) return false;
+		el.click();
+		return true;
+	})()`, page)
+
+	var clicked bool
+	if err := tab.Run(ctx, chromedp.Evaluate(clickJS, &clicked)); err != nil {
+		return false, err
+	}
+	if !clicked {
+		return false, nil
 	}
 
-	for i, a := range articles {
-		time.Sleep(300 * time.Millisecond)
+	readyJS := fmt.Sprintf(`(() => {
+		const cur = document.querySelector("div.gsc-cursor-current-page");
+		return !!cur && cur.textContent.trim() === "%d" &&
+			document.querySelectorAll("div.gsc-webResult").length > 0;
+	})()`, page)
 
-		content, err := k.scrapeArticleContent(taskCtx, a.URL)
-		if err != nil {
-			fmt.Printf("[warn] kompas: failed to fetch content for %s: %v\n", a.URL, err)
-			continue
+	var ready bool
+	if err := tab.Run(ctx, chromedp.Poll(readyJS, &ready, chromedp.WithPollingTimeout(15*time.Second))); err != nil {
+		return false, err
+	}
+	return ready, nil
+}
+
+// FetchArticle mengambil ulang konten dan metadata artikel yang sudah tersimpan.
+func (k *KompasScraper) FetchArticle(ctx context.Context, article *domain.Article) error {
+	return k.scrapeArticle(ctx, article)
+}
+
+func (k *KompasScraper) scrapeArticle(ctx context.Context, article *domain.Article) error {
+	if isMediaURL(article.URL) {
+		return fmt.Errorf("link is a video/photo, not a text article")
+	}
+
+	article.ScrapedAt = time.Now().UTC()
+	article.FetchPath = ""
+
+	// Halaman artikel dirender di server, jadi browser hanya dipakai jika HTML dari
+	// http.Client tidak berisi konten, misal halaman yang dirender dengan JavaScript
+	doc, err := k.fetchArticleHTTP(ctx, article.URL)
+	if err != nil {
+		return err
+	}
+	article.FetchPath = domain.FetchPathHTTP
+
+	if !renderedOnServer(doc) {
+		if httpclient.Replaying(k.client) {
+			return errBrowserReplay
+		}
+		log.Printf("ℹ️  kompas: konten %s tidak ada di HTML, memakai browser", article.URL)
+		if doc, err = k.fetchArticleBrowser(ctx, article.URL); err != nil {
+			return err
 		}
-		articles[i].Content = content
+		article.FetchPath = domain.FetchPathBrowser
 	}
 
-	return articles, nil
+	return parseArticle(doc, article)
 }
 
-func (k *KompasScraper) scrapeArticleContent(ctx context.Context, articleURL string) (string, error) {
-	if strings.Contains(articleURL, "video.kompas.com") || strings.Contains(articleURL, "foto.kompas.com") {
-		return "", fmt.Errorf("link is a video/photo, not a text article")
+// fetchArticleHTTP mengambil halaman artikel dengan http.Client tanpa browser.
+func (k *KompasScraper) fetchArticleHTTP(ctx context.Context, articleURL string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, articleURL, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	opts := chromedp.DefaultExecAllocatorOptions[:]
-	opts = append(opts, chromedp.Flag("headless", true))
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	if execPath := findFirstExecutable("brave", "brave-browser", "chromium-browser"); execPath != "" {
-		opts = append(opts, chromedp.ExecPath(execPath))
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to fetch article: %d", resp.StatusCode)
 	}
 
-	allocCtx, cancel := chromedp.NewExecAllocator(ctx, opts...)
-	defer cancel()
+	return goquery.NewDocumentFromReader(resp.Body)
+}
+
+// fetchArticleBrowser membuka halaman artikel di tab browser dan menunggu kontennya dirender.
+func (k *KompasScraper) fetchArticleBrowser(ctx context.Context, articleURL string) (*goquery.Document, error) {
+	// Navigasi chromedp tidak lewat http.Client, jadi robots.txt dan rate limiter dicek manual
+	if err := httpclient.CheckRobots(ctx, k.client, articleURL, userAgent); err != nil {
+		return nil, err
+	}
+	release, err := httpclient.Acquire(ctx, k.client, articleURL)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
-	taskCtx, cancel := chromedp.NewContext(allocCtx)
-	defer cancel()
+	tab, err := k.browser.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tab.Release()
 
-	var contentHTML string
-	err := chromedp.Run(taskCtx,
-		chromedp.Navigate(articleURL),
+	var pageHTML string
+	err = tab.Navigate(ctx, articleURL,
 		chromedp.WaitVisible("div.read__content", chromedp.ByQuery),
-		chromedp.OuterHTML("div.read__content", &contentHTML, chromedp.ByQuery),
+		chromedp.OuterHTML("html", &pageHTML, chromedp.ByQuery),
 	)
 
 	if err != nil {
-		return "", fmt.Errorf("chromedp failed to retrieve article content: %w", err)
+		return nil, fmt.Errorf("chromedp failed to retrieve article content: %w", err)
 	}
 
-	if contentHTML == "" {
-		return "", fmt.Errorf("article content is empty (selector 'div.read__content' not found by chromedp)")
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse article content HTML: %w", err)
 	}
+	return doc, nil
+}
 
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(contentHTML))
-	if err != nil {
-		return "", fmt.Errorf("failed to parse article content HTML: %w", err)
+// renderedOnServer bernilai true jika HTML sudah berisi konten, baik articleBody JSON-LD
+// maupun teks div.read__content, artinya halaman tidak perlu dirender ulang di browser.
+func renderedOnServer(doc *goquery.Document) bool {
+	if extractor.Extract(doc).Body != "" {
+		return true
 	}
+	return strings.TrimSpace(doc.Find("div.read__content").First().Text()) != ""
+}
+
+// isMediaURL bernilai true untuk halaman video atau foto yang tidak berisi teks artikel.
+func isMediaURL(link string) bool {
+	return strings.Contains(link, "video.kompas.com") || strings.Contains(link, "foto.kompas.com")
+}
 
-	doc.Find("strong:contains('Baca juga:')").Each(func(i int, s *goquery.Selection) {
+// parseArticle mengisi konten dan metadata artikel dari halaman artikel kompas.
+func parseArticle(doc *goquery.Document, article *domain.Article) error {
+	// JSON-LD dan OpenGraph menjadi sumber utama, selector CSS hanya cadangan
+	extractor.Extract(doc).Apply(article)
+
+	if article.Content == "" {
+		article.Content = readContent(doc)
+	}
+	if article.Content == "" {
+		return fmt.Errorf("could not find article content text (JSON-LD articleBody and 'div.read__content' are empty)")
+	}
+
+	if article.PublishedAt.IsZero() {
+		article.PublishedAt = dates.ParseOrZero(extractor.MetaContent(doc, "meta[name='content_PublishedDate']"))
+	}
+	if article.PublishedAt.IsZero() {
+		readTime := strings.TrimSpace(doc.Find("div.read__time").First().Text())
+		article.PublishedAt = dates.ParseOrZero(strings.TrimSpace(strings.TrimPrefix(readTime, "Kompas.com - ")))
+	}
+	if article.UpdatedAt.IsZero() {
+		article.UpdatedAt = dates.ParseOrZero(extractor.MetaContent(doc, "meta[name='content_UpdatedDate']"))
+	}
+
+	if len(article.Authors) == 0 {
+		if author := extractor.MetaContent(doc, "meta[name='content_author']"); author != "" {
+			article.Authors = extractor.SplitList(author)
+		} else {
+			doc.Find("div.read__credit__item a").Each(func(i int, s *goquery.Selection) {
+				if name := strings.TrimSpace(s.Text()); name != "" {
+					article.Authors = append(article.Authors, name)
+				}
+			})
+		}
+	}
+
+	if article.Section == "" {
+		article.Section = extractor.MetaContent(doc, "meta[name='content_category']")
+	}
+
+	if len(article.Tags) == 0 {
+		if tags := extractor.MetaContent(doc, "meta[name='content_tag']"); tags != "" {
+			article.Tags = extractor.SplitList(tags)
+		} else {
+			doc.Find("ul.tag__article__wrap li a").Each(func(i int, s *goquery.Selection) {
+				if tag := strings.TrimSpace(s.Text()); tag != "" {
+					article.Tags = append(article.Tags, tag)
+				}
+			})
+		}
+	}
+
+	doc.Find("div.photo__wrap img").Each(func(i int, s *goquery.Selection) {
+		if src, ok := s.Attr("src"); ok && src != "" && !slices.Contains(article.ImageURLs, src) {
+			article.ImageURLs = append(article.ImageURLs, src)
+		}
+	})
+
+	return nil
+}
+
+// readContent mengambil teks paragraf dari div.read__content tanpa blok "Baca juga".
+func readContent(doc *goquery.Document) string {
+	contentSel := doc.Find("div.read__content").First()
+
+	contentSel.Find("strong:contains('Baca juga:')").Each(func(i int, s *goquery.Selection) {
 		s.Parent().Remove()
 	})
-	doc.Find("strong:contains('Baca juga :')").Each(func(i int, s *goquery.Selection) {
+	contentSel.Find("strong:contains('Baca juga :')").Each(func(i int, s *goquery.Selection) {
 		s.Parent().Remove()
 	})
 
 	var contentBuilder strings.Builder
-	doc.Find("p").Each(func(i int, s *goquery.Selection) {
+	contentSel.Find("p").Each(func(i int, s *goquery.Selection) {
 		contentBuilder.WriteString(strings.TrimSpace(s.Text()) + "\n")
 	})
 
-	content := strings.TrimSpace(contentBuilder.String())
-
-	if content == "" {
-		return "", fmt.Errorf("could not find article content text after goquery parsing")
-	}
-
-	return content, nil
+	return strings.TrimSpace(contentBuilder.String())
 }
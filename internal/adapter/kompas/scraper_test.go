@@ -0,0 +1,363 @@
+package kompas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"the_scrapper/internal/domain"
+)
+
+// Pencarian Google CSE kompas dirender dengan browser, jadi test ini menguji parsing HTML
+// hasil render yang disimpan di testdata. Halaman artikel dan indeks harian diambil lewat
+// HTTP dari httptest.
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return string(raw)
+}
+
+func TestParseSearchResults(t *testing.T) {
+	articles, err := parseSearchResults(readFixture(t, "search.html"))
+	if err != nil {
+		t.Fatalf("parseSearchResults() error = %v", err)
+	}
+
+	tests := []struct {
+		title   string
+		url     string
+		summary string
+	}{
+		{
+			title:   "Banjir Jakarta, 31.000 Warga Mengungsi",
+			url:     "https://megapolitan.kompas.com/read/2020/01/01/08554201/banjir-jakarta-31000-warga-mengungsi",
+			summary: "1 Jan 2020 ... Sebanyak 31.000 warga mengungsi akibat banjir yang melanda Jakarta.",
+		},
+		{
+			title:   "Jokowi Minta Evakuasi Korban Banjir Diutamakan",
+			url:     "https://nasional.kompas.com/read/2020/01/01/10300061/jokowi-minta-evakuasi-korban-banjir-diutamakan",
+			summary: "Presiden Joko Widodo meminta evakuasi korban banjir didahulukan.",
+		},
+	}
+
+	if len(articles) != len(tests) {
+		t.Fatalf("parseSearchResults() returned %d articles, want %d", len(articles), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := articles[i]
+			if got.Title != tt.title {
+				t.Errorf("Title = %q, want %q", got.Title, tt.title)
+			}
+			if got.URL != tt.url {
+				t.Errorf("URL = %q, want %q", got.URL, tt.url)
+			}
+			if got.Summary != tt.summary {
+				t.Errorf("Summary = %q, want %q", got.Summary, tt.summary)
+			}
+			if got.Source != "kompas" {
+				t.Errorf("Source = %q, want kompas", got.Source)
+			}
+		})
+	}
+}
+
+func TestParseArticle(t *testing.T) {
+	tests := []struct {
+		name         string
+		fixture      string
+		content      string
+		canonicalURL string
+		publishedAt  time.Time
+		updatedAt    time.Time
+		authors      []string
+		section      string
+		tags         []string
+		images       []string
+		wantErr      bool
+	}{
+		{
+			name:         "json-ld",
+			fixture:      "article_jsonld.html",
+			content:      "JAKARTA, KOMPAS.com - Sebanyak 31.000 warga mengungsi akibat banjir yang melanda Jakarta sejak Rabu dini hari.",
+			canonicalURL: "https://megapolitan.kompas.com/read/2020/01/01/08554201/banjir-jakarta-31000-warga-mengungsi",
+			publishedAt:  time.Date(2020, 1, 1, 1, 55, 42, 0, time.UTC),
+			updatedAt:    time.Date(2020, 1, 1, 2, 10, 0, 0, time.UTC),
+			authors:      []string{"Jimmy Ramadhan Azhari"},
+			section:      "Megapolitan",
+			tags:         []string{"banjir jakarta", "pengungsi"},
+			images:       []string{"https://asset.kompas.com/crops/banjir.jpg"},
+		},
+		{
+			name:        "css fallback without baca juga",
+			fixture:     "article_css.html",
+			content:     "JAKARTA, KOMPAS.com - Presiden Joko Widodo meminta evakuasi korban banjir didahulukan.\nJokowi menyampaikan hal itu di Istana Bogor.",
+			publishedAt: time.Date(2020, 1, 1, 3, 30, 6, 0, time.UTC),
+			updatedAt:   time.Date(2020, 1, 1, 4, 0, 0, 0, time.UTC),
+			authors:     []string{"Ihsanuddin", "Bayu Galih"},
+			section:     "Nasional",
+			tags:        []string{"jokowi", "banjir"},
+			images:      []string{"https://asset.kompas.com/crops/jokowi.jpg"},
+		},
+		{
+			name:    "empty content",
+			fixture: "article_empty.html",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(readFixture(t, tt.fixture)))
+			if err != nil {
+				t.Fatalf("parse fixture: %v", err)
+			}
+
+			var article domain.Article
+			err = parseArticle(doc, &article)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseArticle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if article.Content != tt.content {
+				t.Errorf("Content = %q, want %q", article.Content, tt.content)
+			}
+			if article.CanonicalURL != tt.canonicalURL {
+				t.Errorf("CanonicalURL = %q, want %q", article.CanonicalURL, tt.canonicalURL)
+			}
+			if !article.PublishedAt.Equal(tt.publishedAt) {
+				t.Errorf("PublishedAt = %v, want %v", article.PublishedAt, tt.publishedAt)
+			}
+			if !article.UpdatedAt.Equal(tt.updatedAt) {
+				t.Errorf("UpdatedAt = %v, want %v", article.UpdatedAt, tt.updatedAt)
+			}
+			if !slices.Equal(article.Authors, tt.authors) {
+				t.Errorf("Authors = %q, want %q", article.Authors, tt.authors)
+			}
+			if article.Section != tt.section {
+				t.Errorf("Section = %q, want %q", article.Section, tt.section)
+			}
+			if !slices.Equal(article.Tags, tt.tags) {
+				t.Errorf("Tags = %q, want %q", article.Tags, tt.tags)
+			}
+			if !slices.Equal(article.ImageURLs, tt.images) {
+				t.Errorf("ImageURLs = %q, want %q", article.ImageURLs, tt.images)
+			}
+		})
+	}
+}
+
+func TestFetchArticleRejectsMedia(t *testing.T) {
+	scraper := NewKompasScraper(nil)
+	for _, url := range []string{
+		"https://video.kompas.com/watch/123/banjir-jakarta",
+		"https://foto.kompas.com/photo/read/2020/01/01/banjir",
+	} {
+		article := domain.Article{URL: url}
+		if err := scraper.FetchArticle(context.Background(), &article); err == nil {
+			t.Errorf("FetchArticle(%q) error = nil, want error for media page", url)
+		}
+	}
+}
+
+func TestFetchArticleHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/read/css":
+			w.Write([]byte(readFixture(t, "article_css.html")))
+		case "/read/jsonld":
+			w.Write([]byte(readFixture(t, "article_jsonld.html")))
+		case "/read/jsonld-only":
+			w.Write([]byte(readFixture(t, "article_jsonld_only.html")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	scraper := NewKompasScraper(srv.Client())
+	defer scraper.Close()
+
+	tests := []struct {
+		path    string
+		content string
+		wantErr bool
+	}{
+		{
+			path:    "/read/css",
+			content: "JAKARTA, KOMPAS.com - Presiden Joko Widodo meminta evakuasi korban banjir didahulukan.\nJokowi menyampaikan hal itu di Istana Bogor.",
+		},
+		{
+			path:    "/read/jsonld",
+			content: "JAKARTA, KOMPAS.com - Sebanyak 31.000 warga mengungsi akibat banjir yang melanda Jakarta sejak Rabu dini hari.",
+		},
+		{
+			path:    "/read/jsonld-only",
+			content: "JAKARTA, KOMPAS.com - Pemprov DKI Jakarta meniadakan aturan ganjil genap selama banjir.",
+		},
+		{
+			path:    "/read/missing",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			article := domain.Article{URL: srv.URL + tt.path, Source: "kompas"}
+			err := scraper.FetchArticle(context.Background(), &article)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchArticle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if article.Content != tt.content {
+				t.Errorf("Content = %q, want %q", article.Content, tt.content)
+			}
+			if article.FetchPath != domain.FetchPathHTTP {
+				t.Errorf("FetchPath = %q, want %q", article.FetchPath, domain.FetchPathHTTP)
+			}
+		})
+	}
+}
+
+func TestRenderedOnServer(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    bool
+	}{
+		{fixture: "article_css.html", want: true},
+		{fixture: "article_jsonld.html", want: true},
+		{fixture: "article_jsonld_only.html", want: true},
+		{fixture: "article_empty.html", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(readFixture(t, tt.fixture)))
+			if err != nil {
+				t.Fatalf("parse fixture: %v", err)
+			}
+			if got := renderedOnServer(doc); got != tt.want {
+				t.Errorf("renderedOnServer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	const cssArticle, jsonldArticle = "article_css.html", "article_jsonld.html"
+	articles := map[string]string{
+		"/read/2020/01/01/08554201/banjir-jakarta-31000-warga-mengungsi":           jsonldArticle,
+		"/read/2020/01/01/10300061/jokowi-minta-evakuasi-korban-banjir-diutamakan": cssArticle,
+		"/read/2020/01/01/13000011/banjir-rendam-bekasi":                           cssArticle,
+	}
+
+	// Halaman pertama memakai tampilan indeks baru, halaman berikutnya tampilan lama
+	// yang hanya berisi satu artikel baru
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := articles[r.URL.Path]
+		switch {
+		case r.URL.Path == "/" && r.URL.Query().Get("date") != "2020-01-01":
+			http.NotFound(w, r)
+			return
+		case r.URL.Path == "/" && r.URL.Query().Get("page") == "1":
+			name = "index.html"
+		case r.URL.Path == "/":
+			name = "index_old.html"
+		case !ok:
+			http.NotFound(w, r)
+			return
+		}
+
+		page := readFixture(t, name)
+		for _, host := range []string{"https://megapolitan.kompas.com", "https://nasional.kompas.com", "https://money.kompas.com"} {
+			page = strings.ReplaceAll(page, host, srv.URL)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	}))
+	defer srv.Close()
+
+	scraper := NewKompasScraper(srv.Client(), WithSearchMode(SearchIndex), WithIndexURL(srv.URL))
+	defer scraper.Close()
+
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, err := scraper.Search(context.Background(), "BANJIR", day, day)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	tests := []struct {
+		title   string
+		path    string
+		content string
+	}{
+		{
+			title:   "Banjir Jakarta, 31.000 Warga Mengungsi",
+			path:    "/read/2020/01/01/08554201/banjir-jakarta-31000-warga-mengungsi",
+			content: "JAKARTA, KOMPAS.com - Sebanyak 31.000 warga mengungsi akibat banjir yang melanda Jakarta sejak Rabu dini hari.",
+		},
+		{
+			title:   "Jokowi Minta Evakuasi Korban Banjir Diutamakan",
+			path:    "/read/2020/01/01/10300061/jokowi-minta-evakuasi-korban-banjir-diutamakan",
+			content: "JAKARTA, KOMPAS.com - Presiden Joko Widodo meminta evakuasi korban banjir didahulukan.\nJokowi menyampaikan hal itu di Istana Bogor.",
+		},
+		{
+			title:   "Banjir Rendam Bekasi",
+			path:    "/read/2020/01/01/13000011/banjir-rendam-bekasi",
+			content: "JAKARTA, KOMPAS.com - Presiden Joko Widodo meminta evakuasi korban banjir didahulukan.\nJokowi menyampaikan hal itu di Istana Bogor.",
+		},
+	}
+
+	if len(got) != len(tests) {
+		t.Fatalf("Search() returned %d articles, want %d", len(got), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			a := got[i]
+			if a.Title != tt.title {
+				t.Errorf("Title = %q, want %q", a.Title, tt.title)
+			}
+			if a.URL != srv.URL+tt.path {
+				t.Errorf("URL = %q, want %q", a.URL, srv.URL+tt.path)
+			}
+			if a.Content != tt.content {
+				t.Errorf("Content = %q, want %q", a.Content, tt.content)
+			}
+			if a.FetchError != "" {
+				t.Errorf("FetchError = %q, want empty", a.FetchError)
+			}
+			if a.FetchPath != domain.FetchPathHTTP {
+				t.Errorf("FetchPath = %q, want %q", a.FetchPath, domain.FetchPathHTTP)
+			}
+		})
+	}
+
+	// Indeks hari kedua tidak bisa dibuka: artikel hari pertama tetap dikembalikan bersama error
+	t.Run("failed day", func(t *testing.T) {
+		got, err := scraper.Search(context.Background(), "banjir", day, day.AddDate(0, 0, 1))
+		if err == nil || !strings.Contains(err.Error(), "2020-01-02") {
+			t.Errorf("Search() error = %v, want an error for 2020-01-02", err)
+		}
+		if len(got) != len(tests) {
+			t.Errorf("Search() returned %d articles, want %d from 2020-01-01", len(got), len(tests))
+		}
+	})
+}
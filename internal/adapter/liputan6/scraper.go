@@ -5,25 +5,78 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"the_scrapper/internal/adapter/extractor"
+	"the_scrapper/internal/adapter/fetch"
+	"the_scrapper/internal/dates"
 	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+const (
+	// Domain adalah domain situs, dipakai untuk konfigurasi limit per sumber di httpclient.
+	Domain = "liputan6.com"
+
+	// DefaultBaseURL adalah alamat halaman pencarian liputan6, bisa diganti dengan WithBaseURL.
+	DefaultBaseURL = "https://www.liputan6.com"
+
+	// DefaultMaxPages adalah batas halaman hasil pencarian per panggilan Search.
+	DefaultMaxPages = 20
+)
+
 type Liputan6Scraper struct {
-	client *http.Client
+	client   *http.Client
+	baseURL  string
+	workers  int
+	maxPages int
+}
+
+// Option mengatur perilaku Liputan6Scraper.
+type Option func(*Liputan6Scraper)
+
+// WithWorkers mengatur jumlah artikel yang diambil kontennya secara bersamaan.
+func WithWorkers(n int) Option {
+	return func(l *Liputan6Scraper) {
+		if n > 0 {
+			l.workers = n
+		}
+	}
+}
+
+// WithMaxPages membatasi jumlah halaman hasil pencarian yang ditelusuri.
+func WithMaxPages(n int) Option {
+	return func(l *Liputan6Scraper) {
+		if n > 0 {
+			l.maxPages = n
+		}
+	}
+}
+
+// WithBaseURL mengganti DefaultBaseURL, tanpa garis miring di akhir.
+func WithBaseURL(baseURL string) Option {
+	return func(l *Liputan6Scraper) {
+		if baseURL != "" {
+			l.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
 }
 
-func NewLiputan6Scraper(client *http.Client) *Liputan6Scraper {
-	return &Liputan6Scraper{client: client}
+func NewLiputan6Scraper(client *http.Client, opts ...Option) *Liputan6Scraper {
+	l := &Liputan6Scraper{client: client, baseURL: DefaultBaseURL, maxPages: DefaultMaxPages, workers: fetch.DefaultWorkers}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
 }
 
 func (l *Liputan6Scraper) Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
-	fromStr := from.Format("02/01/2006")
-	toStr := to.Format("02/01/2006")
+	fromStr := dates.Liputan6Query(from)
+	toStr := dates.Liputan6Query(to)
 
 	params := url.Values{}
 	params.Set("q", query)
@@ -32,7 +85,69 @@ func (l *Liputan6Scraper) Search(ctx context.Context, query string, from, to tim
 	params.Set("order", "latest")
 	params.Set("type", "all")
 
-	urlSearch := fmt.Sprintf("https://www.liputan6.com/search?%s", params.Encode())
+	var articles []domain.Article
+	seen := make(map[string]struct{})
+	limited := false
+	// pageErr adalah kegagalan halaman setelah halaman pertama; hasil halaman sebelumnya
+	// tetap dikembalikan bersamanya agar pemanggil mencatat pencarian ini sebagai gagal
+	var pageErr error
+
+	for page := 1; page <= l.maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageArticles, err := l.fetchSearchPage(ctx, params, page)
+		if err != nil {
+			if page == 1 {
+				return nil, err
+			}
+			pageErr = fmt.Errorf("search page %d: %w", page, err)
+			break
+		}
+
+		added := 0
+		for _, a := range pageArticles {
+			if _, ok := seen[a.URL]; ok {
+				continue
+			}
+			seen[a.URL] = struct{}{}
+			articles = append(articles, a)
+			added++
+		}
+		progress.Report(ctx, progress.Event{Stage: progress.StagePageFetched, Source: "liputan6", Page: page, Count: added})
+
+		if added == 0 {
+			break
+		}
+		limited = page == l.maxPages
+	}
+
+	if limited {
+		progress.Report(ctx, progress.Event{Stage: progress.StageLimitReached, Source: "liputan6", Count: len(articles)})
+		// Pemanggil bisa menghentikan pencarian di sini, misal untuk memecah rentang tanggal
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range articles {
+		progress.Report(ctx, progress.Event{Stage: progress.StageArticleQueued, Source: "liputan6", URL: a.URL})
+	}
+
+	fetch.All(ctx, articles, l.workers, l.scrapeArticle)
+
+	return articles, pageErr
+}
+
+func (l *Liputan6Scraper) fetchSearchPage(ctx context.Context, params url.Values, page int) ([]domain.Article, error) {
+	pageParams := url.Values{}
+	for k, v := range params {
+		pageParams[k] = v
+	}
+	pageParams.Set("page", strconv.Itoa(page))
+
+	urlSearch := fmt.Sprintf("%s/search?%s", l.baseURL, pageParams.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlSearch, nil)
 	if err != nil {
@@ -59,53 +174,84 @@ func (l *Liputan6Scraper) Search(ctx context.Context, query string, from, to tim
 	var articles []domain.Article
 	doc.Find("article.articles--iridescent-list--item").Each(func(i int, s *goquery.Selection) {
 		title := strings.TrimSpace(s.Find("h4.articles--iridescent-list--text-item__title").Text())
-		link, _ := s.Find("a").Attr("href")
+		// Hasil tanpa gambar diawali tautan kategori, jadi tautan judul didahulukan
+		link, ok := s.Find("h4.articles--iridescent-list--text-item__title a").Attr("href")
+		if !ok {
+			link, _ = s.Find("a").Attr("href")
+		}
 		summary := strings.TrimSpace(s.Find("p.articles--iridescent-list--text-item__summary").Text())
 
 		if title != "" && link != "" {
 			articles = append(articles, domain.Article{
-				Title:   title,
-				URL:     link,
-				Summary: summary,
+				Title:    title,
+				URL:      link,
+				Summary:  summary,
+				Source:   "liputan6",
+				Language: "id",
 			})
 		}
 	})
 
-	for i, a := range articles {
-		content, err := l.scrapeArticleContent(ctx, a.URL)
-		if err != nil {
-			fmt.Printf("[warn] gagal ambil konten %s: %v\n", a.URL, err)
-			continue
-		}
-		articles[i].Content = content
-	}
-
 	return articles, nil
 }
 
-func (l *Liputan6Scraper) scrapeArticleContent(ctx context.Context, articleURL string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, articleURL, nil)
+// FetchArticle mengambil ulang konten dan metadata artikel yang sudah tersimpan.
+func (l *Liputan6Scraper) FetchArticle(ctx context.Context, article *domain.Article) error {
+	return l.scrapeArticle(ctx, article)
+}
+
+func (l *Liputan6Scraper) scrapeArticle(ctx context.Context, article *domain.Article) error {
+	article.ScrapedAt = time.Now().UTC()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, article.URL, nil)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	req.Header.Set("User-agent", "Mozilla/5.0 (compatible; Liputan6Scraper/1.0)")
 
 	resp, err := l.client.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("failed to fetch article: %d", resp.StatusCode)
+		return fmt.Errorf("failed to fetch article: %d", resp.StatusCode)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	// JSON-LD dan OpenGraph menjadi sumber utama, selector CSS hanya cadangan
+	extractor.Extract(doc).Apply(article)
+
+	if article.Content == "" {
+		article.Content = strings.TrimSpace(doc.Find("div.article-content-body__item-content").Text())
+	}
+
+	if article.PublishedAt.IsZero() {
+		datetime, _ := doc.Find("time.read-page--header--author__datetime").Attr("datetime")
+		article.PublishedAt = dates.ParseOrZero(strings.TrimSpace(datetime))
+	}
+
+	if len(article.Authors) == 0 {
+		if author := extractor.MetaContent(doc, "meta[name='author']"); author != "" {
+			article.Authors = extractor.SplitList(author)
+		} else {
+			doc.Find("span.read-page--header--author__name").Each(func(i int, s *goquery.Selection) {
+				if name := strings.TrimSpace(s.Text()); name != "" {
+					article.Authors = append(article.Authors, name)
+				}
+			})
+		}
+	}
+
+	if len(article.Tags) == 0 {
+		article.Tags = extractor.SplitList(extractor.MetaContent(doc, "meta[name='keywords']"))
 	}
 
-	content := strings.TrimSpace(doc.Find("div.article-content-body__item-content").Text())
-	return content, nil
+	return nil
 }
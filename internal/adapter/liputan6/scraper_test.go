@@ -0,0 +1,286 @@
+package liputan6
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
+	"the_scrapper/internal/testutil"
+)
+
+// articleHost adalah host artikel di fixture yang diganti dengan alamat httptest.Server.
+const articleHost = "https://www.liputan6.com"
+
+// articlePages memetakan path artikel di halaman pencarian ke fixture-nya.
+var articlePages = map[string]string{
+	"/news/read/4146201/banjir-jakarta-bpbd-catat-169-titik-pengungsian": "article_jsonld.html",
+	"/bisnis/read/4146355/krl-lintas-bogor-kembali-normal-usai-banjir":   "article_css.html",
+	"/news/read/4146420/pengungsi-banjir-cipinang-melayu-butuh-selimut":  "article_jsonld.html",
+	"/bisnis/read/4146478/tol-jakarta-cikampek-ditutup-akibat-banjir":    "article_css.html",
+}
+
+// searchFixtures memilih fixture liputan6: halaman pencarian kedua berisi dua artikel baru dan
+// satu duplikat halaman pertama, halaman berikutnya kosong.
+func searchFixtures(w http.ResponseWriter, r *http.Request) string {
+	switch {
+	case r.URL.Path == "/search" && r.URL.Query().Get("page") == "1":
+		return "search.html"
+	case r.URL.Path == "/search" && r.URL.Query().Get("page") == "2":
+		return "search_page2.html"
+	case r.URL.Path == "/search":
+		return "search_empty.html"
+	}
+	name, ok := articlePages[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+	}
+	return name
+}
+
+func newFixtureServer(t *testing.T) *httptest.Server {
+	return testutil.NewFixtureServer(t, articleHost, searchFixtures)
+}
+
+func TestSearch(t *testing.T) {
+	srv := newFixtureServer(t)
+	scraper := NewLiputan6Scraper(srv.Client(), WithBaseURL(srv.URL))
+
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	articles, err := scraper.Search(context.Background(), "banjir jakarta", day, day)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	tests := []struct {
+		title   string
+		path    string
+		summary string
+		content string
+	}{
+		{
+			title:   "Banjir Jakarta, BPBD Catat 169 Titik Pengungsian",
+			path:    "/news/read/4146201/banjir-jakarta-bpbd-catat-169-titik-pengungsian",
+			summary: "BPBD DKI Jakarta mencatat 169 titik pengungsian warga terdampak banjir.",
+			content: "Liputan6.com, Jakarta - BPBD DKI Jakarta mencatat 169 titik pengungsian warga terdampak banjir hingga Rabu sore.",
+		},
+		{
+			title:   "KRL Lintas Bogor Kembali Normal Usai Banjir",
+			path:    "/bisnis/read/4146355/krl-lintas-bogor-kembali-normal-usai-banjir",
+			summary: "PT KCI memastikan perjalanan KRL lintas Bogor kembali normal.",
+			content: "Liputan6.com, Jakarta - PT KCI memastikan perjalanan KRL lintas Bogor kembali normal.\nPenumpang diminta tetap memantau informasi resmi.",
+		},
+		{
+			title:   "Pengungsi Banjir Cipinang Melayu Butuh Selimut",
+			path:    "/news/read/4146420/pengungsi-banjir-cipinang-melayu-butuh-selimut",
+			summary: "Pengungsi di Cipinang Melayu membutuhkan selimut dan makanan bayi.",
+			content: "Liputan6.com, Jakarta - BPBD DKI Jakarta mencatat 169 titik pengungsian warga terdampak banjir hingga Rabu sore.",
+		},
+		{
+			title:   "Tol Jakarta-Cikampek Ditutup Akibat Banjir",
+			path:    "/bisnis/read/4146478/tol-jakarta-cikampek-ditutup-akibat-banjir",
+			summary: "Jasa Marga menutup sebagian ruas tol Jakarta-Cikampek.",
+			content: "Liputan6.com, Jakarta - PT KCI memastikan perjalanan KRL lintas Bogor kembali normal.\nPenumpang diminta tetap memantau informasi resmi.",
+		},
+	}
+
+	if len(articles) != len(tests) {
+		t.Fatalf("Search() returned %d articles, want %d", len(articles), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := articles[i]
+			if got.FetchError != "" {
+				t.Fatalf("FetchError = %q", got.FetchError)
+			}
+			if got.Title != tt.title {
+				t.Errorf("Title = %q, want %q", got.Title, tt.title)
+			}
+			if want := srv.URL + tt.path; got.URL != want {
+				t.Errorf("URL = %q, want %q", got.URL, want)
+			}
+			if got.Summary != tt.summary {
+				t.Errorf("Summary = %q, want %q", got.Summary, tt.summary)
+			}
+			if got.Content != tt.content {
+				t.Errorf("Content = %q, want %q", got.Content, tt.content)
+			}
+			if got.Source != "liputan6" {
+				t.Errorf("Source = %q, want liputan6", got.Source)
+			}
+		})
+	}
+}
+
+func TestSearchPaging(t *testing.T) {
+	srv := newFixtureServer(t)
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Halaman 1 dan 2 masing-masing berisi dua artikel baru; halaman 3 kosong
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    int
+		pages   int
+		limited bool
+	}{
+		{name: "all pages", want: 4, pages: 3},
+		{name: "max pages", opts: []Option{WithMaxPages(1)}, want: 2, pages: 1, limited: true},
+		{name: "max pages on the last page", opts: []Option{WithMaxPages(2)}, want: 4, pages: 2, limited: true},
+		{name: "max pages above the total", opts: []Option{WithMaxPages(5)}, want: 4, pages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			pages, limited := 0, false
+			ctx := progress.WithReporter(context.Background(), func(e progress.Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch e.Stage {
+				case progress.StagePageFetched:
+					pages++
+				case progress.StageLimitReached:
+					limited = true
+				}
+			})
+
+			scraper := NewLiputan6Scraper(srv.Client(), append([]Option{WithBaseURL(srv.URL)}, tt.opts...)...)
+			articles, err := scraper.Search(ctx, "banjir jakarta", day, day)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(articles) != tt.want {
+				t.Errorf("Search() returned %d articles, want %d", len(articles), tt.want)
+			}
+			if pages != tt.pages {
+				t.Errorf("fetched %d search pages, want %d", pages, tt.pages)
+			}
+			if limited != tt.limited {
+				t.Errorf("limit reached = %v, want %v", limited, tt.limited)
+			}
+		})
+	}
+}
+
+func TestFetchArticle(t *testing.T) {
+	srv := newFixtureServer(t)
+	scraper := NewLiputan6Scraper(srv.Client(), WithBaseURL(srv.URL))
+
+	tests := []struct {
+		name         string
+		path         string
+		canonicalURL string
+		publishedAt  time.Time
+		updatedAt    time.Time
+		authors      []string
+		section      string
+		tags         []string
+		wantErr      bool
+	}{
+		{
+			name:         "json-ld",
+			path:         "/news/read/4146201/banjir-jakarta-bpbd-catat-169-titik-pengungsian",
+			canonicalURL: "/news/read/4146201/banjir-jakarta-bpbd-catat-169-titik-pengungsian",
+			publishedAt:  time.Date(2020, 1, 1, 10, 2, 0, 0, time.UTC),
+			authors:      []string{"Ika Defianti"},
+			section:      "News",
+			tags:         []string{"banjir", "bpbd dki", "pengungsian"},
+		},
+		{
+			name:        "css fallback",
+			path:        "/bisnis/read/4146355/krl-lintas-bogor-kembali-normal-usai-banjir",
+			publishedAt: time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC),
+			authors:     []string{"Athika Rahma", "Nurmayanti"},
+			tags:        []string{"krl", "banjir", "bogor"},
+		},
+		{
+			name:    "not found",
+			path:    "/news/read/1/tidak-ada",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := domain.Article{URL: srv.URL + tt.path, Source: "liputan6"}
+			err := scraper.FetchArticle(context.Background(), &article)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchArticle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			wantCanonical := ""
+			if tt.canonicalURL != "" {
+				wantCanonical = srv.URL + tt.canonicalURL
+			}
+			if article.CanonicalURL != wantCanonical {
+				t.Errorf("CanonicalURL = %q, want %q", article.CanonicalURL, wantCanonical)
+			}
+			if !article.PublishedAt.Equal(tt.publishedAt) {
+				t.Errorf("PublishedAt = %v, want %v", article.PublishedAt, tt.publishedAt)
+			}
+			if !article.UpdatedAt.Equal(tt.updatedAt) {
+				t.Errorf("UpdatedAt = %v, want %v", article.UpdatedAt, tt.updatedAt)
+			}
+			if !slices.Equal(article.Authors, tt.authors) {
+				t.Errorf("Authors = %q, want %q", article.Authors, tt.authors)
+			}
+			if article.Section != tt.section {
+				t.Errorf("Section = %q, want %q", article.Section, tt.section)
+			}
+			if !slices.Equal(article.Tags, tt.tags) {
+				t.Errorf("Tags = %q, want %q", article.Tags, tt.tags)
+			}
+		})
+	}
+}
+
+func TestSearchFailedFirstPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	scraper := NewLiputan6Scraper(srv.Client(), WithBaseURL(srv.URL))
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := scraper.Search(context.Background(), "banjir", day, day); err == nil {
+		t.Fatal("Search() error = nil, want error for 503 on the first page")
+	}
+}
+
+func TestSearchFailedLaterPage(t *testing.T) {
+	srv := testutil.NewFixtureServer(t, articleHost, func(w http.ResponseWriter, r *http.Request) string {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return ""
+		}
+		return searchFixtures(w, r)
+	})
+
+	scraper := NewLiputan6Scraper(srv.Client(), WithBaseURL(srv.URL))
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	articles, err := scraper.Search(context.Background(), "banjir", day, day)
+	if err == nil {
+		t.Fatal("Search() error = nil, want error for 503 on the second page")
+	}
+	// Artikel halaman pertama tetap dikembalikan bersama error
+	if len(articles) != 2 {
+		t.Fatalf("Search() returned %d articles, want the 2 from the first page", len(articles))
+	}
+	for _, a := range articles {
+		if a.Content == "" {
+			t.Errorf("article %s was not fetched", a.URL)
+		}
+	}
+}
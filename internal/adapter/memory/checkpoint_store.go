@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// CheckpointStore menyimpan unit backfill di memori, sehingga --resume tidak berlaku
+// antar proses.
+type CheckpointStore struct {
+	mu    sync.Mutex
+	units map[string]domain.BackfillUnit
+}
+
+var _ repository.CheckpointStore = (*CheckpointStore)(nil)
+
+func NewCheckpointStore() *CheckpointStore {
+	return &CheckpointStore{units: make(map[string]domain.BackfillUnit)}
+}
+
+func (s *CheckpointStore) Load(ctx context.Context, source, query string) ([]domain.BackfillUnit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var units []domain.BackfillUnit
+	for _, unit := range s.units {
+		if unit.Source == source && unit.Query == query {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i].Day.Before(units[j].Day) })
+	return units, nil
+}
+
+func (s *CheckpointStore) Save(ctx context.Context, unit domain.BackfillUnit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.units[unit.Key()] = unit
+	return nil
+}
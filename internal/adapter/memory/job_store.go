@@ -0,0 +1,82 @@
+// Package memory menyimpan job dan checkpoint di memori proses, untuk menjalankan
+// aplikasi tanpa MongoDB. Data hilang saat proses berhenti.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// JobStore menyimpan scrape job di memori.
+type JobStore struct {
+	mu   sync.RWMutex
+	jobs map[string]domain.Job
+}
+
+var _ repository.JobStore = (*JobStore)(nil)
+
+func NewJobStore() *JobStore {
+	return &JobStore{jobs: make(map[string]domain.Job)}
+}
+
+func (s *JobStore) Create(ctx context.Context, job *domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; ok {
+		return fmt.Errorf("insert job %s: duplicate id", job.ID)
+	}
+	s.jobs[job.ID] = copyJob(*job)
+	return nil
+}
+
+func (s *JobStore) Update(ctx context.Context, job *domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	s.jobs[job.ID] = copyJob(*job)
+	return nil
+}
+
+func (s *JobStore) Get(ctx context.Context, id string) (*domain.Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	job = copyJob(job)
+	return &job, nil
+}
+
+func (s *JobStore) ListByStatus(ctx context.Context, statuses ...domain.JobStatus) ([]domain.Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var jobs []domain.Job
+	for _, job := range s.jobs {
+		for _, status := range statuses {
+			if job.Status == status {
+				jobs = append(jobs, copyJob(job))
+				break
+			}
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// copyJob menyalin job agar slice Errors tidak dipakai bersama pemanggil.
+func copyJob(job domain.Job) domain.Job {
+	job.Errors = append([]string(nil), job.Errors...)
+	return job
+}
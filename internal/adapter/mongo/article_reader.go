@@ -0,0 +1,286 @@
+package mongo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+const (
+	// DefaultQueryLimit dan MaxQueryLimit membatasi jumlah artikel per halaman query.
+	DefaultQueryLimit = 50
+	MaxQueryLimit     = 500
+
+	// streamBatchSize adalah jumlah dokumen per batch cursor saat Stream.
+	streamBatchSize = 500
+)
+
+var (
+	_ repository.ArticleReader   = (*ArticleStore)(nil)
+	_ repository.ArticleStreamer = (*ArticleStore)(nil)
+)
+
+// articleDocument menambahkan _id MongoDB ke domain.Article saat membaca.
+type articleDocument struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	domain.Article `bson:",inline"`
+}
+
+func (d articleDocument) toArticle() domain.Article {
+	article := d.Article
+	article.ID = d.ID.Hex()
+	return article
+}
+
+// pageCursor adalah posisi terakhir sebuah halaman: published_at dan _id artikel terakhir.
+type pageCursor struct {
+	PublishedAt time.Time `json:"p"`
+	ID          string    `json:"i"`
+}
+
+func encodeCursor(c pageCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (pageCursor, primitive.ObjectID, error) {
+	var c pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, primitive.NilObjectID, repository.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, primitive.NilObjectID, repository.ErrInvalidCursor
+	}
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return c, primitive.NilObjectID, repository.ErrInvalidCursor
+	}
+	return c, id, nil
+}
+
+// collections mengembalikan koleksi untuk sumber yang diminta, atau semua koleksi
+// "<source>_articles" jika sources kosong.
+func (s *ArticleStore) collections(ctx context.Context, sources []string) ([]string, error) {
+	if s.collectionName != "" {
+		return []string{s.collectionName}, nil
+	}
+	if len(sources) > 0 {
+		names := make([]string, 0, len(sources))
+		for _, source := range sources {
+			names = append(names, s.CollectionName(source))
+		}
+		return names, nil
+	}
+
+	names, err := s.db.ListCollectionNames(ctx, bson.M{"name": bson.M{"$regex": "_articles$"}})
+	if err != nil {
+		return nil, fmt.Errorf("list article collections: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// filterDocument mengubah ArticleFilter menjadi filter MongoDB.
+func (s *ArticleStore) filterDocument(f repository.ArticleFilter) bson.M {
+	filter := bson.M{}
+
+	// Koleksi tetap berisi banyak sumber, jadi sumber difilter lewat field source
+	if s.collectionName != "" && len(f.Sources) > 0 {
+		filter["source"] = bson.M{"$in": f.Sources}
+	}
+
+	published := bson.M{}
+	if !f.From.IsZero() {
+		published["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		published["$lt"] = f.To
+	}
+	if len(published) > 0 {
+		filter["published_at"] = published
+	}
+
+	if f.Keyword != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(f.Keyword), Options: "i"}
+		filter["$or"] = bson.A{
+			bson.M{"title": pattern},
+			bson.M{"content": pattern},
+		}
+	}
+	if f.Author != "" {
+		filter["authors"] = primitive.Regex{Pattern: regexp.QuoteMeta(f.Author), Options: "i"}
+	}
+	if f.FetchFailed {
+		// String apa pun yang tidak kosong lebih besar dari ""
+		filter["fetch_error"] = bson.M{"$gt": ""}
+	}
+
+	return filter
+}
+
+// unionPipeline mencocokkan filter di koleksi pertama lalu menggabungkan koleksi lain
+// dengan $unionWith (MongoDB 4.4+).
+func (s *ArticleStore) unionPipeline(names []string, f repository.ArticleFilter) mongo.Pipeline {
+	filter := s.filterDocument(f)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	for _, name := range names[1:] {
+		pipeline = append(pipeline, bson.D{{Key: "$unionWith", Value: bson.M{
+			"coll":     name,
+			"pipeline": bson.A{bson.M{"$match": filter}},
+		}}})
+	}
+	return pipeline
+}
+
+// Find membaca satu halaman artikel dari semua koleksi sumber yang diminta
+// menggunakan $unionWith (MongoDB 4.4+), diurutkan berdasarkan published_at lalu _id.
+func (s *ArticleStore) Find(ctx context.Context, q repository.ArticleQuery) (repository.ArticlePage, error) {
+	var page repository.ArticlePage
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	if limit > MaxQueryLimit {
+		limit = MaxQueryLimit
+	}
+
+	names, err := s.collections(ctx, q.Sources)
+	if err != nil {
+		return page, err
+	}
+	if len(names) == 0 {
+		page.Articles = []domain.Article{}
+		return page, nil
+	}
+
+	pipeline := s.unionPipeline(names, q.ArticleFilter)
+
+	direction, op := -1, "$lt"
+	if q.Ascending {
+		direction, op = 1, "$gt"
+	}
+
+	if q.Cursor != "" {
+		c, id, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return page, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"published_at": bson.M{op: c.PublishedAt}},
+			bson.M{"published_at": c.PublishedAt, "_id": bson.M{op: id}},
+		}}}})
+	}
+
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "published_at", Value: direction}, {Key: "_id", Value: direction}}}},
+		bson.D{{Key: "$limit", Value: limit + 1}},
+	)
+
+	if len(q.Fields) > 0 {
+		projection := bson.M{"_id": 1, "published_at": 1}
+		for _, field := range q.Fields {
+			projection[field] = 1
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	}
+
+	cursor, err := s.db.Collection(names[0]).Aggregate(ctx, pipeline)
+	if err != nil {
+		return page, fmt.Errorf("query articles: %w", err)
+	}
+
+	var docs []articleDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return page, fmt.Errorf("decode articles: %w", err)
+	}
+
+	if len(docs) > limit {
+		docs = docs[:limit]
+		last := docs[len(docs)-1]
+		page.NextCursor = encodeCursor(pageCursor{PublishedAt: last.PublishedAt, ID: last.ID.Hex()})
+	}
+
+	page.Articles = make([]domain.Article, len(docs))
+	for i, doc := range docs {
+		page.Articles[i] = doc.toArticle()
+	}
+	return page, nil
+}
+
+// FindByID mencari artikel berdasarkan _id di semua koleksi sumber.
+func (s *ArticleStore) FindByID(ctx context.Context, id string) (*domain.Article, error) {
+	oid, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
+	if err != nil {
+		return nil, repository.ErrNotFound
+	}
+
+	names, err := s.collections(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		var doc articleDocument
+		err := s.db.Collection(name).FindOne(ctx, bson.M{"_id": oid}).Decode(&doc)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find article %s in %s: %w", id, name, err)
+		}
+		article := doc.toArticle()
+		return &article, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+// Stream membaca semua artikel yang cocok dengan filter lewat satu cursor MongoDB,
+// diurutkan berdasarkan published_at lalu _id, dan memanggil fn untuk setiap artikel.
+// Hanya satu batch cursor yang ditahan di memori; pengurutan boleh memakai disk.
+func (s *ArticleStore) Stream(ctx context.Context, filter repository.ArticleFilter, fn func(domain.Article) error) error {
+	names, err := s.collections(ctx, filter.Sources)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	pipeline := append(s.unionPipeline(names, filter),
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "published_at", Value: 1}, {Key: "_id", Value: 1}}}},
+	)
+	opts := options.Aggregate().SetAllowDiskUse(true).SetBatchSize(streamBatchSize)
+
+	cursor, err := s.db.Collection(names[0]).Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return fmt.Errorf("query articles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc articleDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("decode article: %w", err)
+		}
+		if err := fn(doc.toArticle()); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,100 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+var _ repository.ArticleStatsReader = (*ArticleStore)(nil)
+
+// statsDocument adalah hasil $group per sumber.
+type statsDocument struct {
+	Source         string    `bson:"_id"`
+	Total          int       `bson:"total"`
+	FetchFailed    int       `bson:"fetch_failed"`
+	FetchedHTTP    int       `bson:"fetched_http"`
+	FetchedBrowser int       `bson:"fetched_browser"`
+	FirstPublished time.Time `bson:"first_published"`
+	LastPublished  time.Time `bson:"last_published"`
+	LastScraped    time.Time `bson:"last_scraped"`
+}
+
+// Stats menghitung jumlah artikel, artikel yang gagal diambil, jalur pengambilan
+// (http atau browser), serta rentang tanggal terbit dan waktu scrape terakhir untuk
+// setiap sumber, diurutkan berdasarkan nama sumber.
+func (s *ArticleStore) Stats(ctx context.Context, filter repository.ArticleFilter) ([]repository.SourceStats, error) {
+	names, err := s.collections(ctx, filter.Sources)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: s.filterDocument(filter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$source",
+			"total": bson.M{"$sum": 1},
+			"fetch_failed": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$fetch_error", ""}}, 1, 0,
+			}}},
+			"fetched_http":    countEqual("$fetch_path", domain.FetchPathHTTP),
+			"fetched_browser": countEqual("$fetch_path", domain.FetchPathBrowser),
+			"first_published": bson.M{"$min": "$published_at"},
+			"last_published":  bson.M{"$max": "$published_at"},
+			"last_scraped":    bson.M{"$max": "$scraped_at"},
+		}}},
+	}
+
+	// Koleksi tetap bisa berisi beberapa sumber, jadi hasil digabung per sumber
+	bySource := make(map[string]*repository.SourceStats)
+	for _, name := range names {
+		cursor, err := s.db.Collection(name).Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("aggregate stats %s: %w", name, err)
+		}
+		var docs []statsDocument
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, fmt.Errorf("decode stats %s: %w", name, err)
+		}
+
+		for _, doc := range docs {
+			stats, ok := bySource[doc.Source]
+			if !ok {
+				stats = &repository.SourceStats{Source: doc.Source, FirstPublished: doc.FirstPublished}
+				bySource[doc.Source] = stats
+			}
+			stats.Total += doc.Total
+			stats.FetchFailed += doc.FetchFailed
+			stats.FetchedHTTP += doc.FetchedHTTP
+			stats.FetchedBrowser += doc.FetchedBrowser
+			if doc.FirstPublished.Before(stats.FirstPublished) {
+				stats.FirstPublished = doc.FirstPublished
+			}
+			if doc.LastPublished.After(stats.LastPublished) {
+				stats.LastPublished = doc.LastPublished
+			}
+			if doc.LastScraped.After(stats.LastScraped) {
+				stats.LastScraped = doc.LastScraped
+			}
+		}
+	}
+
+	result := make([]repository.SourceStats, 0, len(bySource))
+	for _, stats := range bySource {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Source < result[j].Source })
+	return result, nil
+}
+
+// countEqual adalah akumulator $sum yang menghitung dokumen dengan field bernilai value.
+func countEqual(field, value string) bson.M {
+	return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{field, value}}, 1, 0}}}
+}
@@ -0,0 +1,222 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// ArticleStore menyimpan artikel ke koleksi "<source>_articles" dengan upsert
+// berdasarkan canonical URL.
+type ArticleStore struct {
+	db             *mongo.Database
+	collectionName string
+
+	mu      sync.Mutex
+	indexed map[string]bool
+}
+
+var _ repository.ArticleStore = (*ArticleStore)(nil)
+
+// ArticleStoreOption mengatur perilaku ArticleStore.
+type ArticleStoreOption func(*ArticleStore)
+
+// WithCollectionName menyimpan semua sumber ke satu koleksi tetap.
+func WithCollectionName(name string) ArticleStoreOption {
+	return func(s *ArticleStore) {
+		s.collectionName = name
+	}
+}
+
+// NewArticleStore membuat ArticleStore baru di atas database MongoDB.
+func NewArticleStore(db *mongo.Database, opts ...ArticleStoreOption) *ArticleStore {
+	s := &ArticleStore{db: db, indexed: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CollectionName mengembalikan nama koleksi untuk sebuah sumber.
+func (s *ArticleStore) CollectionName(source string) string {
+	if s.collectionName != "" {
+		return s.collectionName
+	}
+	return fmt.Sprintf("%s_articles", source)
+}
+
+// EnsureIndexes membuat unique index canonical_url pada koleksi sumber jika belum ada.
+// Index bersifat partial agar dokumen lama tanpa canonical_url tidak bentrok.
+func (s *ArticleStore) EnsureIndexes(ctx context.Context, source string) error {
+	name := s.CollectionName(source)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexed[name] {
+		return nil
+	}
+
+	model := mongo.IndexModel{
+		Keys: bson.D{{Key: "canonical_url", Value: 1}},
+		Options: options.Index().
+			SetName("canonical_url_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"canonical_url": bson.M{"$type": "string"}}),
+	}
+	if _, err := s.db.Collection(name).Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("create canonical_url index on %s: %w", name, err)
+	}
+
+	s.indexed[name] = true
+	return nil
+}
+
+// searchFields adalah field yang berasal dari halaman pencarian, selalu ditulis ulang.
+var searchFields = []string{"title", "url", "canonical_url", "summary", "source", "language"}
+
+// Save melakukan bulk upsert.
+//
+// Data hasil parsing halaman artikel yang sudah tersimpan tidak ditimpa nilai kosong:
+// jika FetchError terisi, hanya field halaman pencarian yang diperbarui, sehingga pasangan
+// content dan fetch_error serta scraped_at yang lama tetap utuh; jika berhasil, field
+// hasil parsing yang kosong dilewati, fetch_error dikosongkan dan scraped_at diperbarui.
+// Field yang tidak ditulis tetap diisi saat dokumen baru dibuat. Artikel tanpa URL
+// dihitung sebagai Invalid.
+func (s *ArticleStore) Save(ctx context.Context, source string, articles []domain.Article) (repository.SaveResult, error) {
+	var result repository.SaveResult
+	if len(articles) == 0 {
+		return result, nil
+	}
+
+	if err := s.EnsureIndexes(ctx, source); err != nil {
+		return result, err
+	}
+
+	// Artikel dengan canonical URL sama dalam satu batch cukup ditulis sekali (yang terakhir menang)
+	order := make([]string, 0, len(articles))
+	byKey := make(map[string]domain.Article, len(articles))
+	for _, article := range articles {
+		key := articleKey(article)
+		if key == "" {
+			result.Invalid++
+			continue
+		}
+		if _, ok := byKey[key]; !ok {
+			order = append(order, key)
+		} else {
+			result.Unchanged++
+		}
+		byKey[key] = article
+	}
+
+	models := make([]mongo.WriteModel, 0, len(order))
+	for _, key := range order {
+		article := byKey[key]
+		article.CanonicalURL = key
+
+		doc, err := toDocument(article)
+		if err != nil {
+			return result, err
+		}
+		set, onInsert := splitUpdate(article, doc)
+
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"canonical_url": key}).
+			SetUpdate(bson.M{
+				"$set":         set,
+				"$setOnInsert": onInsert,
+			}).
+			SetUpsert(true))
+	}
+
+	if len(models) == 0 {
+		return result, nil
+	}
+
+	opts := options.BulkWrite().SetOrdered(false)
+	res, err := s.db.Collection(s.CollectionName(source)).BulkWrite(ctx, models, opts)
+	if res != nil {
+		result.Inserted += int(res.UpsertedCount)
+		result.Updated += int(res.ModifiedCount)
+		result.Unchanged += int(res.MatchedCount - res.ModifiedCount)
+	}
+	if err != nil {
+		return result, fmt.Errorf("bulk upsert failed: %w", err)
+	}
+	return result, nil
+}
+
+// splitUpdate membagi doc menjadi field yang ditulis ($set) dan field yang hanya diisi
+// saat dokumen baru dibuat ($setOnInsert).
+func splitUpdate(article domain.Article, doc bson.M) (set, onInsert bson.M) {
+	keep := func(field string) bool {
+		if slices.Contains(searchFields, field) {
+			return true
+		}
+		// Konten gagal diambil: data lama yang sudah tersimpan dipertahankan
+		if article.FetchError != "" {
+			return false
+		}
+		return !emptyExtracted(article, field)
+	}
+
+	set, onInsert = bson.M{}, bson.M{}
+	for field, value := range doc {
+		if keep(field) {
+			set[field] = value
+		} else {
+			onInsert[field] = value
+		}
+	}
+	return set, onInsert
+}
+
+// emptyExtracted bernilai true jika field hasil parsing halaman artikel kosong.
+func emptyExtracted(a domain.Article, field string) bool {
+	switch field {
+	case "content":
+		return a.Content == ""
+	case "published_at":
+		return a.PublishedAt.IsZero()
+	case "updated_at":
+		return a.UpdatedAt.IsZero()
+	case "authors":
+		return len(a.Authors) == 0
+	case "section":
+		return a.Section == ""
+	case "tags":
+		return len(a.Tags) == 0
+	case "image_urls":
+		return len(a.ImageURLs) == 0
+	case "fetch_path":
+		return a.FetchPath == ""
+	}
+	return false
+}
+
+func articleKey(article domain.Article) string {
+	if article.CanonicalURL != "" {
+		return domain.CanonicalizeURL(article.CanonicalURL)
+	}
+	return domain.CanonicalizeURL(article.URL)
+}
+
+func toDocument(article domain.Article) (bson.M, error) {
+	raw, err := bson.Marshal(article)
+	if err != nil {
+		return nil, fmt.Errorf("marshal article %s: %w", article.URL, err)
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal article %s: %w", article.URL, err)
+	}
+	return doc, nil
+}
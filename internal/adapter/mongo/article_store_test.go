@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+func TestSplitUpdate(t *testing.T) {
+	full := domain.Article{
+		Title:       "Banjir Jakarta",
+		URL:         "https://example.com/banjir",
+		Summary:     "Ringkasan",
+		Content:     "Isi artikel",
+		PublishedAt: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC),
+		Authors:     []string{"Penulis"},
+		Source:      "kompas",
+		Language:    "id",
+		ScrapedAt:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		FetchPath:   domain.FetchPathHTTP,
+	}
+	failed := full
+	failed.Content, failed.Authors, failed.FetchPath = "", nil, ""
+	failed.FetchError = "context deadline exceeded"
+
+	tests := []struct {
+		name    string
+		article domain.Article
+		set     []string
+	}{
+		{
+			name:    "fetched",
+			article: full,
+			set:     []string{"authors", "canonical_url", "content", "fetch_error", "fetch_path", "language", "published_at", "scraped_at", "source", "summary", "title", "url"},
+		},
+		{
+			name:    "fetch failed keeps stored content and fetch_error",
+			article: failed,
+			set:     []string{"canonical_url", "language", "source", "summary", "title", "url"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := toDocument(tt.article)
+			if err != nil {
+				t.Fatalf("toDocument() error = %v", err)
+			}
+			set, onInsert := splitUpdate(tt.article, doc)
+
+			var got []string
+			for field := range set {
+				got = append(got, field)
+				if _, ok := onInsert[field]; ok {
+					t.Errorf("field %q is in both $set and $setOnInsert", field)
+				}
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.set) {
+				t.Errorf("$set fields = %q, want %q", got, tt.set)
+			}
+			if len(set)+len(onInsert) != len(doc) {
+				t.Errorf("split has %d fields, want %d", len(set)+len(onInsert), len(doc))
+			}
+		})
+	}
+}
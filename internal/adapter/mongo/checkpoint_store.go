@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// DefaultCheckpointCollection adalah nama koleksi untuk checkpoint backfill.
+const DefaultCheckpointCollection = "backfill_checkpoints"
+
+// CheckpointStore menyimpan checkpoint backfill di MongoDB dengan Key unit sebagai _id.
+type CheckpointStore struct {
+	collection *mongo.Collection
+}
+
+var _ repository.CheckpointStore = (*CheckpointStore)(nil)
+
+// checkpointDocument menambahkan _id ke domain.BackfillUnit.
+type checkpointDocument struct {
+	ID                  string `bson:"_id"`
+	domain.BackfillUnit `bson:",inline"`
+}
+
+// NewCheckpointStore membuat CheckpointStore di koleksi DefaultCheckpointCollection.
+func NewCheckpointStore(db *mongo.Database) *CheckpointStore {
+	return &CheckpointStore{collection: db.Collection(DefaultCheckpointCollection)}
+}
+
+func (s *CheckpointStore) Load(ctx context.Context, source, query string) ([]domain.BackfillUnit, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"source": source, "query": query}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list checkpoints: %w", err)
+	}
+
+	var docs []checkpointDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode checkpoints: %w", err)
+	}
+
+	units := make([]domain.BackfillUnit, len(docs))
+	for i, doc := range docs {
+		units[i] = doc.BackfillUnit
+	}
+	return units, nil
+}
+
+func (s *CheckpointStore) Save(ctx context.Context, unit domain.BackfillUnit) error {
+	doc := checkpointDocument{ID: unit.Key(), BackfillUnit: unit}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.collection.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, opts); err != nil {
+		return fmt.Errorf("save checkpoint %s: %w", doc.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// DefaultJobCollection adalah nama koleksi untuk scrape job.
+const DefaultJobCollection = "scrape_jobs"
+
+// JobStore menyimpan scrape job di MongoDB.
+type JobStore struct {
+	collection *mongo.Collection
+}
+
+var _ repository.JobStore = (*JobStore)(nil)
+
+// NewJobStore membuat JobStore di koleksi DefaultJobCollection.
+func NewJobStore(db *mongo.Database) *JobStore {
+	return &JobStore{collection: db.Collection(DefaultJobCollection)}
+}
+
+func (s *JobStore) Create(ctx context.Context, job *domain.Job) error {
+	if _, err := s.collection.InsertOne(ctx, job); err != nil {
+		return fmt.Errorf("insert job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+func (s *JobStore) Update(ctx context.Context, job *domain.Job) error {
+	res, err := s.collection.ReplaceOne(ctx, bson.M{"_id": job.ID}, job)
+	if err != nil {
+		return fmt.Errorf("update job %s: %w", job.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (s *JobStore) Get(ctx context.Context, id string) (*domain.Job, error) {
+	var job domain.Job
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find job %s: %w", id, err)
+	}
+	return &job, nil
+}
+
+func (s *JobStore) ListByStatus(ctx context.Context, statuses ...domain.JobStatus) ([]domain.Job, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"status": bson.M{"$in": statuses}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+
+	var jobs []domain.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("decode jobs: %w", err)
+	}
+	return jobs, nil
+}
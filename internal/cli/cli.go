@@ -0,0 +1,175 @@
+// Package cli berisi perintah baris perintah the_scrapper: scrape, serve, export, stats
+// dan reparse. Pengaturan dibaca dari flag, lalu variabel lingkungan, lalu file konfigurasi.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// Exit code yang dikembalikan Run, agar cron bisa membedakan kegagalan sebagian dan total.
+const (
+	ExitOK      = 0 // semua berhasil
+	ExitFailure = 1 // gagal total atau error yang menghentikan perintah
+	ExitUsage   = 2 // flag atau argumen tidak valid
+	ExitPartial = 3 // sebagian unit/artikel/sumber gagal
+)
+
+// Name adalah nama program pada pesan bantuan.
+const Name = "the_scrapper"
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) int
+}
+
+var commands = []command{
+	{"scrape", "Scrape a date range day by day with checkpoints", runScrape},
+	{"serve", "Run the HTTP API server", runServe},
+	{"export", "Export stored articles", runExport},
+	{"stats", "Show stored article counts per source", runStats},
+	{"reparse", "Re-fetch and re-parse stored articles", runReparse},
+}
+
+// Run menjalankan perintah dari args (tanpa nama program) dan mengembalikan exit code.
+func Run(args []string) int {
+	global, configPath := newGlobalFlagSet()
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return ExitUsage
+	}
+
+	name := global.Arg(0)
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(global.Output(), "unknown command %q\n\n", name)
+		global.Usage()
+		return ExitUsage
+	}
+
+	loadEnv()
+	if err := loadConfigFile(*configPath); err != nil {
+		log.Printf("❌ %v", err)
+		return ExitFailure
+	}
+	if err := applyCassetteFlags(global); err != nil {
+		fmt.Fprintln(global.Output(), err)
+		global.Usage()
+		return ExitUsage
+	}
+
+	// Ctrl+C atau SIGTERM menghentikan perintah dengan rapi
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return cmd.run(ctx, global.Args()[1:])
+}
+
+// RunDefault seperti Run, tetapi menjalankan perintah name jika args tidak menyebut
+// perintah. Flag global tetap bisa ditulis di depan, misal "--config x.json --port 9000".
+func RunDefault(name string, args []string) int {
+	global, _ := newGlobalFlagSet()
+
+	// Flag global dipisahkan dulu agar nama perintah bisa disisipkan sesudahnya
+	i := 0
+	for i < len(args) {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			break
+		}
+		flagName, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if global.Lookup(flagName) == nil {
+			break
+		}
+		i++
+		if !hasValue && i < len(args) {
+			i++
+		}
+	}
+
+	if i < len(args) && findCommand(args[i]) != nil {
+		return Run(args)
+	}
+	withCommand := append(slices.Clip(args[:i]), name)
+	return Run(append(withCommand, args[i:]...))
+}
+
+// newGlobalFlagSet membuat flag yang berlaku untuk semua perintah.
+func newGlobalFlagSet() (global *flag.FlagSet, configPath *string) {
+	global = flag.NewFlagSet(Name, flag.ContinueOnError)
+	configPath = global.String("config", "", "JSON config file (default $"+configEnv+", or "+defaultConfigFile+" if present)")
+	global.String("cassette", "", "record or replay HTTP responses, or off (env CASSETTE_MODE)")
+	global.String("cassette-dir", defaultCassetteDir, "directory of recorded HTTP responses (env CASSETTE_DIR)")
+	global.Usage = func() { printUsage(global.Output(), global) }
+	return global, configPath
+}
+
+// findCommand mengembalikan perintah bernama name, atau nil jika tidak ada.
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func printUsage(w io.Writer, global *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: %s [--config file] [--cassette record|replay] <command> [flags]\n\nCommands:\n", Name)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nGlobal flags:\n")
+	global.PrintDefaults()
+	fmt.Fprintf(w, "\nRun '%s <command> --help' for command flags.\n", Name)
+}
+
+// newFlagSet membuat FlagSet untuk satu perintah.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(Name+" "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n\nFlags:\n", Name, name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags mem-parse flag perintah dan mengembalikan exit code jika perintah harus berhenti.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK, false
+		}
+		return ExitUsage, false
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return ExitUsage, false
+	}
+	return 0, true
+}
+
+// usageError mencetak pesan kesalahan flag dan mengembalikan ExitUsage.
+func usageError(fs *flag.FlagSet, format string, args ...any) int {
+	fmt.Fprintf(fs.Output(), format+"\n", args...)
+	fs.Usage()
+	return ExitUsage
+}
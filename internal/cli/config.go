@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"the_scrapper/internal/dates"
+	"the_scrapper/internal/repository"
+	"the_scrapper/internal/usecase"
+)
+
+const (
+	// configEnv menunjuk file konfigurasi jika --config tidak diberikan.
+	configEnv = "SCRAPPER_CONFIG"
+
+	// defaultConfigFile dibaca jika ada dan tidak ada file lain yang ditunjuk.
+	defaultConfigFile = "config.json"
+
+	dateLayout = "2006-01-02"
+)
+
+// loadEnv memuat variabel lingkungan dari file .env
+func loadEnv() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️  File .env tidak ditemukan, menggunakan variabel lingkungan dari sistem.")
+	}
+}
+
+// loadConfigFile membaca file konfigurasi JSON berisi nama variabel lingkungan dan nilainya,
+// contoh {"MONGO_URI": "mongodb://localhost:27017", "DETIK_RPS": 5}. Nilai hanya dipakai jika
+// variabel tersebut belum diatur, sehingga urutannya flag, lalu lingkungan, lalu file.
+func loadConfigFile(path string) error {
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv(configEnv)
+		explicit = path != ""
+	}
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("decode config %s: %w", path, err)
+	}
+
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string, float64, bool:
+			os.Setenv(key, fmt.Sprint(v))
+		default:
+			return fmt.Errorf("config %s: %s must be a string, number or boolean", path, key)
+		}
+	}
+	return nil
+}
+
+// setting mengembalikan nilai flag jika diberikan di baris perintah, jika tidak nilai
+// variabel lingkungan envKey (termasuk dari file konfigurasi), jika tidak nilai default flag.
+func setting(fs *flag.FlagSet, name, envKey string) string {
+	f := fs.Lookup(name)
+	set := false
+	fs.Visit(func(v *flag.Flag) {
+		if v.Name == name {
+			set = true
+		}
+	})
+	if set {
+		return f.Value.String()
+	}
+	if value := os.Getenv(envKey); value != "" {
+		return value
+	}
+	return f.DefValue
+}
+
+// splitSources memisahkan daftar sumber yang dipisah koma.
+func splitSources(value string) []string {
+	var sources []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			sources = append(sources, s)
+		}
+	}
+	return sources
+}
+
+// parseDate membaca tanggal YYYY-MM-DD sebagai tengah malam di loc; string kosong
+// menghasilkan waktu nol.
+func parseDate(name, value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(dateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s %q, use YYYY-MM-DD", name, value)
+	}
+	return t, nil
+}
+
+// filterFlags adalah flag filter artikel yang dipakai export, stats dan reparse.
+type filterFlags struct {
+	sources string
+	from    string
+	to      string
+	keyword string
+	author  string
+}
+
+func addFilterFlags(fs *flag.FlagSet) *filterFlags {
+	f := &filterFlags{}
+	fs.StringVar(&f.sources, "source", "", "comma-separated sources (default all)")
+	fs.StringVar(&f.from, "from", "", "first publication date, YYYY-MM-DD")
+	fs.StringVar(&f.to, "to", "", "last publication date (inclusive), YYYY-MM-DD")
+	fs.StringVar(&f.keyword, "q", "", "keyword in title or content")
+	fs.StringVar(&f.author, "author", "", "author name")
+	return f
+}
+
+// filter mengubah flag menjadi ArticleFilter; seperti di API, tanggal dibaca sebagai hari
+// WIB dan --to bersifat inklusif.
+func (f *filterFlags) filter() (repository.ArticleFilter, error) {
+	var filter repository.ArticleFilter
+
+	from, err := parseDate("from", f.from, dates.Jakarta)
+	if err != nil {
+		return filter, err
+	}
+	to, err := parseDate("to", f.to, dates.Jakarta)
+	if err != nil {
+		return filter, err
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return filter, errors.New("--to must not be before --from")
+	}
+
+	for _, source := range splitSources(f.sources) {
+		if source != usecase.AllSources {
+			filter.Sources = append(filter.Sources, source)
+		}
+	}
+	filter.From, filter.To = from, to
+	filter.Keyword = strings.TrimSpace(f.keyword)
+	filter.Author = strings.TrimSpace(f.author)
+	return filter, nil
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"the_scrapper/internal/dates"
+)
+
+func TestFilterFlagsDays(t *testing.T) {
+	flags := &filterFlags{from: "2020-01-01", to: "2020-01-31"}
+	filter, err := flags.filter()
+	if err != nil {
+		t.Fatalf("filter() error = %v", err)
+	}
+
+	if want := time.Date(2020, 1, 1, 0, 0, 0, 0, dates.Jakarta); !filter.From.Equal(want) {
+		t.Errorf("From = %v, want %v", filter.From, want)
+	}
+	// --to inklusif: batas atasnya tengah malam WIB awal 1 Februari
+	if want := time.Date(2020, 2, 1, 0, 0, 0, 0, dates.Jakarta); !filter.To.Equal(want) {
+		t.Errorf("To = %v, want %v", filter.To, want)
+	}
+}
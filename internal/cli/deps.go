@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"the_scrapper/internal/adapter/detik"
+	"the_scrapper/internal/adapter/filesink"
+	"the_scrapper/internal/adapter/httpclient"
+	"the_scrapper/internal/adapter/kompas"
+	"the_scrapper/internal/adapter/liputan6"
+	mongoAdapter "the_scrapper/internal/adapter/mongo"
+	"the_scrapper/internal/handler/httpapi"
+	"the_scrapper/internal/repository"
+	"the_scrapper/internal/usecase"
+)
+
+// defaultCassetteDir adalah direktori cassette jika --cassette-dir dan CASSETTE_DIR kosong.
+const defaultCassetteDir = "cassettes"
+
+// applyCassetteFlags memvalidasi --cassette dan --cassette-dir lalu meneruskannya lewat
+// CASSETTE_MODE dan CASSETTE_DIR, karena semua perintah membuat client dengan newHTTPClient.
+func applyCassetteFlags(global *flag.FlagSet) error {
+	mode := setting(global, "cassette", "CASSETTE_MODE")
+	if _, err := httpclient.ParseCassetteMode(mode); err != nil {
+		return err
+	}
+	os.Setenv("CASSETTE_MODE", mode)
+	os.Setenv("CASSETTE_DIR", setting(global, "cassette-dir", "CASSETTE_DIR"))
+	return nil
+}
+
+// newHTTPClient membuat HTTP client bersama dengan limit per sumber yang bisa diatur
+// lewat DETIK_RPS, KOMPAS_MAX_CONCURRENT, LIPUTAN6_BURST, dan seterusnya.
+// <SOURCE>_IGNORE_ROBOTS=true melewati robots.txt untuk sumber yang sudah memberi izin.
+// Setiap retry HTTP dicatat ke log. CASSETTE_MODE=record|replay merekam atau memutar ulang
+// response dari CASSETTE_DIR.
+func newHTTPClient() *http.Client {
+	opts := []httpclient.Option{
+		httpclient.WithRetryHook(func(e httpclient.RetryEvent) {
+			log.Printf("🔁 %s", e)
+		}),
+	}
+
+	sources := []struct{ envPrefix, domain string }{
+		{"DETIK", detik.Domain},
+		{"KOMPAS", kompas.Domain},
+		{"LIPUTAN6", liputan6.Domain},
+	}
+	for _, src := range sources {
+		opts = append(opts, httpclient.WithHostLimit(src.domain, httpclient.LimitFromEnv(src.envPrefix, httpclient.DefaultLimit)))
+		if os.Getenv(src.envPrefix+"_IGNORE_ROBOTS") == "true" {
+			log.Printf("⚠️  robots.txt diabaikan untuk %s", src.domain)
+			opts = append(opts, httpclient.WithRobotsOverride(src.domain))
+		}
+	}
+
+	// Mode sudah divalidasi applyCassetteFlags
+	if mode, _ := httpclient.ParseCassetteMode(os.Getenv("CASSETTE_MODE")); mode != httpclient.CassetteOff {
+		dir := os.Getenv("CASSETTE_DIR")
+		if mode == httpclient.CassetteRecord {
+			log.Printf("📼 Response HTTP direkam ke %s", dir)
+		} else {
+			log.Printf("📼 Response HTTP diputar ulang dari %s, tanpa akses jaringan", dir)
+		}
+		opts = append(opts, httpclient.WithCassette(dir, mode))
+	}
+
+	return httpclient.NewHTTPClient(opts...)
+}
+
+// newScrapers membuat scraper semua sumber dengan client dari newHTTPClient. Browser kompas
+// diatur lewat KOMPAS_BROWSER_PATH, KOMPAS_BROWSER_FLAGS, KOMPAS_BROWSER_PROXY,
+// KOMPAS_BROWSER_USER_DATA_DIR, atau KOMPAS_BROWSER_URL untuk browser remote.
+// KOMPAS_SEARCH_MODE=index memakai indeks harian kompas alih-alih Google CSE.
+// Pemanggil wajib memanggil closeScrapers.
+func newScrapers() map[string]repository.Scraper {
+	browserOpts := kompas.BrowserOptionsFromEnv("KOMPAS")
+	if url := os.Getenv("KOMPAS_BROWSER_URL"); url != "" {
+		log.Printf("🌐 Browser kompas remote: %s", url)
+	}
+
+	mode, err := kompas.ParseSearchMode(os.Getenv("KOMPAS_SEARCH_MODE"))
+	if err != nil {
+		log.Printf("⚠️  %v, memakai pencarian %s", err, mode)
+	} else if mode == kompas.SearchIndex {
+		log.Println("ℹ️  Kompas dicari lewat indeks harian")
+	}
+
+	return httpapi.NewScraperFactory(newHTTPClient(),
+		kompas.WithBrowserOptions(browserOpts...),
+		kompas.WithSearchMode(mode),
+	)
+}
+
+// checkBrowser memastikan browser kompas tersedia, agar browser yang tidak ditemukan
+// menjadi error saat start, bukan saat scraping berjalan.
+func checkBrowser(scrapers map[string]repository.Scraper) error {
+	k, ok := scrapers["kompas"].(*kompas.KompasScraper)
+	if !ok {
+		return nil
+	}
+	err := k.CheckBrowser()
+	if errors.Is(err, kompas.ErrBrowserNotFound) {
+		return fmt.Errorf("%w (set KOMPAS_BROWSER_PATH, or KOMPAS_BROWSER_URL for a remote browser)", err)
+	}
+	return err
+}
+
+// disableScraper menutup scraper source lalu menggantinya dengan UnavailableScraper.
+func disableScraper(scrapers map[string]repository.Scraper, source string, err error) {
+	if closer, ok := scrapers[source].(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Printf("⚠️  Gagal menutup scraper %s: %v", source, err)
+		}
+	}
+	scrapers[source] = usecase.UnavailableScraper{Err: err}
+}
+
+// closeScrapers menutup scraper yang memegang resource, misal browser kompas.
+func closeScrapers(scrapers map[string]repository.Scraper) {
+	for source, scraper := range scrapers {
+		closer, ok := scraper.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			log.Printf("⚠️  Gagal menutup scraper %s: %v", source, err)
+		}
+	}
+}
+
+// mongoFlags adalah flag koneksi MongoDB (MONGO_URI, DB_NAME, COLLECTION_NAME).
+type mongoFlags struct {
+	fs         *flag.FlagSet
+	collection bool
+}
+
+// addMongoFlags mendaftarkan --mongo-uri dan --db. Jika collection bernilai true, --collection
+// ikut didaftarkan untuk menyimpan semua sumber di satu koleksi tetap.
+func addMongoFlags(fs *flag.FlagSet, collection bool) *mongoFlags {
+	fs.String("mongo-uri", "", "MongoDB connection URI (env MONGO_URI)")
+	fs.String("db", "", "MongoDB database name (env DB_NAME)")
+	if collection {
+		fs.String("collection", "", "single collection for all sources instead of <source>_articles (env COLLECTION_NAME)")
+	}
+	return &mongoFlags{fs: fs, collection: collection}
+}
+
+// connect membuka koneksi MongoDB. Pemanggil wajib memanggil disconnect.
+func (m *mongoFlags) connect(ctx context.Context) (*mongo.Client, *mongo.Database, error) {
+	uri := setting(m.fs, "mongo-uri", "MONGO_URI")
+	dbName := setting(m.fs, "db", "DB_NAME")
+	if uri == "" || dbName == "" {
+		return nil, nil, errors.New("set --mongo-uri and --db, or MONGO_URI and DB_NAME")
+	}
+
+	client, err := mongoAdapter.NewClient(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, client.Database(dbName), nil
+}
+
+// articleStore membuat ArticleStore sesuai --collection.
+func (m *mongoFlags) articleStore(db *mongo.Database) *mongoAdapter.ArticleStore {
+	if !m.collection {
+		return mongoAdapter.NewArticleStore(db)
+	}
+	return mongoAdapter.NewArticleStore(db, mongoAdapter.WithCollectionName(setting(m.fs, "collection", "COLLECTION_NAME")))
+}
+
+// disconnect menutup koneksi MongoDB.
+func disconnect(client *mongo.Client) {
+	if err := client.Disconnect(context.Background()); err != nil {
+		log.Printf("⚠️  Gagal disconnect dari MongoDB: %v", err)
+	}
+}
+
+// Tujuan penyimpanan artikel untuk --sink.
+const (
+	sinkMongo  = "mongo"
+	sinkDir    = "dir"
+	sinkStdout = "stdout"
+)
+
+// sinkFlags memilih tujuan penyimpanan artikel (SINK, SINK_DIR) beserta flag MongoDB.
+type sinkFlags struct {
+	fs *flag.FlagSet
+	db *mongoFlags
+}
+
+// addSinkFlags mendaftarkan --sink, --sink-dir dan flag MongoDB.
+func addSinkFlags(fs *flag.FlagSet, collection bool) *sinkFlags {
+	fs.String("sink", sinkMongo, "where articles are stored: mongo, dir or stdout (env SINK)")
+	fs.String("sink-dir", "data", "directory for --sink dir, one JSONL file per source and day (env SINK_DIR)")
+	return &sinkFlags{fs: fs, db: addMongoFlags(fs, collection)}
+}
+
+// kind mengembalikan nilai --sink yang sudah divalidasi.
+func (s *sinkFlags) kind() (string, error) {
+	switch kind := setting(s.fs, "sink", "SINK"); kind {
+	case sinkMongo, sinkDir, sinkStdout:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("invalid --sink %q: use mongo, dir or stdout", kind)
+	}
+}
+
+// dir mengembalikan direktori --sink-dir.
+func (s *sinkFlags) dir() string {
+	return setting(s.fs, "sink-dir", "SINK_DIR")
+}
+
+// sink adalah tujuan penyimpanan yang sudah dibuka. client dan database hanya diisi
+// untuk sink mongo.
+type sink struct {
+	kind     string
+	articles repository.ArticleStore
+	client   *mongo.Client
+	database *mongo.Database
+}
+
+// open membuka sink. Koneksi MongoDB hanya dibuat untuk sink mongo; pemanggil wajib
+// memanggil close.
+func (s *sinkFlags) open(ctx context.Context) (*sink, error) {
+	kind, err := s.kind()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case sinkDir:
+		return &sink{kind: kind, articles: filesink.NewDirStore(s.dir())}, nil
+	case sinkStdout:
+		return &sink{kind: kind, articles: filesink.NewWriterStore(os.Stdout)}, nil
+	}
+
+	client, database, err := s.db.connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connect MongoDB: %w", err)
+	}
+	return &sink{kind: kind, articles: s.db.articleStore(database), client: client, database: database}, nil
+}
+
+// close menutup koneksi MongoDB jika ada.
+func (s *sink) close() {
+	if s.client != nil {
+		disconnect(s.client)
+	}
+}
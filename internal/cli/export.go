@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"the_scrapper/internal/adapter/export"
+	"the_scrapper/internal/domain"
+)
+
+// runExport menulis artikel tersimpan sebagai JSON Lines, CSV atau Parquet.
+func runExport(ctx context.Context, args []string) int {
+	fs := newFlagSet("export", "[flags]")
+	filters := addFilterFlags(fs)
+	formatFlag := fs.String("format", string(export.JSONL), "output format: jsonl, csv or parquet")
+	gzipped := fs.Bool("gzip", false, "gzip the output (parquet uses GZIP column compression)")
+	out := fs.String("out", "-", "output file, - for stdout")
+	db := addMongoFlags(fs, true)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	filter, err := filters.filter()
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+	format, err := export.ParseFormat(*formatFlag)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	client, database, err := db.connect(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal koneksi MongoDB: %v", err)
+		return ExitFailure
+	}
+	defer disconnect(client)
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Printf("❌ Gagal membuat file %s: %v", *out, err)
+			return ExitFailure
+		}
+		defer f.Close()
+		w = f
+	}
+
+	writer, err := export.NewWriter(w, format, *gzipped)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		return ExitFailure
+	}
+
+	// Artikel dialirkan dari cursor MongoDB, jadi memori tidak bergantung pada jumlah artikel
+	n := 0
+	err = db.articleStore(database).Stream(ctx, filter, func(article domain.Article) error {
+		n++
+		return writer.Write(article)
+	})
+	if err != nil {
+		log.Printf("❌ Export gagal setelah %d artikel: %v", n, err)
+		return ExitFailure
+	}
+	if err := writer.Close(); err != nil {
+		log.Printf("❌ Gagal menulis export: %v", err)
+		return ExitFailure
+	}
+
+	log.Printf("✅ %d artikel diekspor (%s)", n, format)
+	return ExitOK
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"the_scrapper/internal/usecase"
+)
+
+// runReparse mengambil ulang artikel tersimpan; secara default hanya yang gagal diambil.
+func runReparse(ctx context.Context, args []string) int {
+	fs := newFlagSet("reparse", "[flags]")
+	filters := addFilterFlags(fs)
+	all := fs.Bool("all", false, "re-fetch every matching article, not only those with a fetch_error")
+	workers := fs.Int("workers", usecase.DefaultReparseWorkers, "articles fetched concurrently")
+	db := addMongoFlags(fs, true)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	filter, err := filters.filter()
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+	filter.FetchFailed = !*all
+
+	client, database, err := db.connect(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal koneksi MongoDB: %v", err)
+		return ExitFailure
+	}
+	defer disconnect(client)
+
+	store := db.articleStore(database)
+	scrapers := newScrapers()
+	defer closeScrapers(scrapers)
+	// Artikel kompas biasanya diambil lewat HTTP, jadi tanpa browser reparse tetap berjalan
+	if err := checkBrowser(scrapers); err != nil {
+		log.Printf("⚠️  Browser kompas tidak tersedia, artikel kompas yang butuh browser akan gagal: %v", err)
+	}
+
+	service := usecase.NewReparseService(store, store, scrapers, *workers)
+	summary, err := service.Run(ctx, filter)
+	if err != nil {
+		log.Printf("🛑 Reparse berhenti: %v", err)
+	}
+
+	fmt.Printf("\n📊 Ringkasan: %d artikel diproses, %d berhasil, %d gagal, %d dilewati (%d diperbarui, %d tidak berubah).\n",
+		summary.Processed, summary.Fetched, summary.Failed, summary.Skipped,
+		summary.Counts.Updated, summary.Counts.Unchanged)
+
+	switch {
+	case err == nil && summary.Failed == 0:
+		return ExitOK
+	case summary.Fetched > 0:
+		return ExitPartial
+	default:
+		return ExitFailure
+	}
+}
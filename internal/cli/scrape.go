@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"the_scrapper/internal/adapter/checkpoint"
+	"the_scrapper/internal/adapter/memory"
+	mongoAdapter "the_scrapper/internal/adapter/mongo"
+	"the_scrapper/internal/repository"
+	"the_scrapper/internal/usecase"
+)
+
+// checkpointFile adalah nama file checkpoint di --sink-dir.
+const checkpointFile = "checkpoint.json"
+
+// runScrape menjalankan backfill per hari dengan checkpoint.
+func runScrape(ctx context.Context, args []string) int {
+	fs := newFlagSet("scrape", "--source <sources> --query <query> --from <date> --to <date> [flags]")
+	sources := fs.String("source", "", "comma-separated sources: detik, kompas, liputan6 or all (required)")
+	query := fs.String("query", "", "search query (required)")
+	fromFlag := fs.String("from", "", "first day, YYYY-MM-DD (required)")
+	toFlag := fs.String("to", "", "last day (inclusive), YYYY-MM-DD (default --from)")
+	resume := fs.Bool("resume", false, "skip days that are done and retry failed ones")
+	fs.String("checkpoint", "", "JSON checkpoint file (env CHECKPOINT_FILE, default the "+mongoAdapter.DefaultCheckpointCollection+" collection, or "+checkpointFile+" in --sink-dir)")
+	unitTimeout := fs.Duration("unit-timeout", usecase.DefaultUnitTimeout, "timeout for scraping one source for one day")
+	sinks := addSinkFlags(fs, true)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *query == "" {
+		return usageError(fs, "--query is required")
+	}
+	if *fromFlag == "" {
+		return usageError(fs, "--from is required")
+	}
+	from, err := parseDate("from", *fromFlag, time.UTC)
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+	to := from
+	if *toFlag != "" {
+		if to, err = parseDate("to", *toFlag, time.UTC); err != nil {
+			return usageError(fs, "%v", err)
+		}
+	}
+	if to.Before(from) {
+		return usageError(fs, "--to must not be before --from")
+	}
+
+	scrapers := newScrapers()
+	defer closeScrapers(scrapers)
+	names, err := usecase.NewMultiSearchService(scrapers).ResolveSources(splitSources(*sources))
+	if err != nil {
+		return usageError(fs, "invalid --source %q: use detik, kompas, liputan6 or all", *sources)
+	}
+
+	kind, err := sinks.kind()
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+	checkpointPath := setting(fs, "checkpoint", "CHECKPOINT_FILE")
+	if kind == sinkStdout && checkpointPath == "" && *resume {
+		return usageError(fs, "--resume with --sink stdout needs --checkpoint")
+	}
+
+	if slices.Contains(names, "kompas") {
+		if err := checkBrowser(scrapers); err != nil {
+			log.Printf("❌ Browser kompas tidak tersedia: %v", err)
+			return ExitFailure
+		}
+	}
+
+	// Dengan --sink stdout, artikel ditulis ke stdout sehingga ringkasan dipindah ke stderr
+	var out io.Writer = os.Stdout
+	if kind == sinkStdout {
+		out = os.Stderr
+	}
+
+	target, err := sinks.open(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal membuka sink %s: %v", kind, err)
+		return ExitFailure
+	}
+	defer target.close()
+
+	var checkpoints repository.CheckpointStore
+	switch {
+	case checkpointPath != "":
+		checkpoints = checkpoint.NewFileStore(checkpointPath)
+	case kind == sinkMongo:
+		checkpoints = mongoAdapter.NewCheckpointStore(target.database)
+	case kind == sinkDir:
+		if err := os.MkdirAll(sinks.dir(), 0o755); err != nil {
+			log.Printf("❌ Gagal membuat direktori %s: %v", sinks.dir(), err)
+			return ExitFailure
+		}
+		checkpoints = checkpoint.NewFileStore(filepath.Join(sinks.dir(), checkpointFile))
+	default:
+		checkpoints = memory.NewCheckpointStore()
+	}
+
+	period := fmt.Sprintf("%s s/d %s", from.Format("02-01-2006"), to.Format("02-01-2006"))
+	if *resume {
+		fmt.Fprintf(out, "🔄 Melanjutkan backfill %s dari checkpoint...\n", period)
+	} else {
+		fmt.Fprintf(out, "🚀 Memulai scraping untuk %s...\n", period)
+	}
+
+	// Setiap hari per sumber adalah satu unit yang dicatat di checkpoint.
+	// Retry dilakukan per request HTTP oleh httpclient, bukan mengulang seluruh pencarian
+	service := usecase.NewBackfillService(scrapers, target.articles, checkpoints, usecase.WithUnitTimeout(*unitTimeout))
+	summary, err := service.Run(ctx, usecase.BackfillRequest{
+		Sources: names,
+		Query:   *query,
+		From:    from,
+		To:      to,
+		Resume:  *resume,
+	})
+	if err != nil {
+		log.Printf("🛑 Backfill berhenti: %v", err)
+		fmt.Fprintln(out, "ℹ️ Jalankan ulang dengan --resume untuk melanjutkan.")
+	}
+
+	fmt.Fprintf(out, "\n📊 Ringkasan: %d hari selesai, %d gagal, %d dilewati (%d baru, %d diperbarui, %d tidak berubah).\n",
+		summary.Done, summary.Failed, summary.Skipped,
+		summary.Counts.Inserted, summary.Counts.Updated, summary.Counts.Unchanged)
+	if summary.Counts.Invalid > 0 {
+		fmt.Fprintf(out, "⚠️  %d artikel tanpa URL tidak disimpan.\n", summary.Counts.Invalid)
+	}
+
+	if len(summary.FailedUnits) > 0 {
+		fmt.Fprintln(out, "❌ Hari yang masih gagal:")
+		for _, unit := range summary.FailedUnits {
+			fmt.Fprintf(out, "   - %s %s (percobaan ke-%d): %s\n", unit.Day.Format("02-01-2006"), unit.Source, unit.Attempts, unit.Error)
+		}
+		fmt.Fprintln(out, "ℹ️ Jalankan ulang dengan --resume untuk mengulang hari yang gagal.")
+	}
+
+	succeeded := summary.Done + summary.Skipped
+	switch {
+	case err == nil && summary.Failed == 0:
+		fmt.Fprintf(out, "\n🎉 Scraping selesai untuk periode %s.\n", period)
+		return ExitOK
+	case succeeded > 0:
+		return ExitPartial
+	default:
+		return ExitFailure
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"the_scrapper/internal/adapter/memory"
+	mongoAdapter "the_scrapper/internal/adapter/mongo"
+	"the_scrapper/internal/handler/httpapi"
+	"the_scrapper/internal/repository"
+	"the_scrapper/internal/usecase"
+)
+
+// runServe menjalankan API server sampai ctx dibatalkan.
+func runServe(ctx context.Context, args []string) int {
+	fs := newFlagSet("serve", "[flags]")
+	fs.String("port", "8080", "port to listen on (env PORT)")
+	sinks := addSinkFlags(fs, false)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	kind, err := sinks.kind()
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	// === Koneksi MongoDB atau sink file ===
+	target, err := sinks.open(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal membuka sink %s: %v", kind, err)
+		return ExitFailure
+	}
+	defer target.close()
+
+	// Dengan MongoDB, artikel disimpan per sumber ("<source>_articles")
+	articleStore := target.articles
+
+	// === Inisialisasi Handler API ===
+	scraperFactory := newScrapers()
+	defer closeScrapers(scraperFactory)
+	// Tanpa browser server tetap berjalan; permintaan kompas dijawab 503
+	if err := checkBrowser(scraperFactory); err != nil {
+		log.Printf("⚠️  Browser kompas tidak tersedia, kompas dinonaktifkan: %v", err)
+		disableScraper(scraperFactory, "kompas", err)
+	}
+	scrapeHandler := httpapi.NewScrapeHandler(articleStore, scraperFactory)
+
+	// Job asinkron disimpan di MongoDB; job yang belum selesai dilanjutkan saat server start.
+	// Tanpa MongoDB, job hanya disimpan di memori
+	var jobStore repository.JobStore = memory.NewJobStore()
+	if target.database != nil {
+		jobStore = mongoAdapter.NewJobStore(target.database)
+	}
+	jobService := usecase.NewJobService(jobStore, articleStore, scraperFactory, usecase.DefaultMaxConcurrentJobs)
+	if n, err := jobService.Resume(context.Background()); err != nil {
+		log.Printf("⚠️  Gagal melanjutkan job lama: %v", err)
+	} else if n > 0 {
+		log.Printf("🔄 %d job yang belum selesai dilanjutkan", n)
+	}
+	jobHandler := httpapi.NewJobHandler(jobService)
+
+	// === Routes ===
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scrape", scrapeHandler.HandleScrape)
+	mux.HandleFunc("POST /jobs", jobHandler.HandleCreate)
+	mux.HandleFunc("GET /jobs/{id}", jobHandler.HandleGet)
+	mux.HandleFunc("DELETE /jobs/{id}", jobHandler.HandleCancel)
+	mux.HandleFunc("GET /jobs/{id}/events", jobHandler.HandleEvents)
+
+	// Artikel hanya bisa dibaca kembali jika sink mendukungnya (MongoDB)
+	if reader, ok := articleStore.(repository.ArticleReader); ok {
+		articleHandler := httpapi.NewArticleHandler(reader)
+		mux.HandleFunc("GET /articles", articleHandler.HandleList)
+		mux.HandleFunc("GET /articles/{id}", articleHandler.HandleGet)
+	} else {
+		log.Printf("ℹ️ Sink %s tidak mendukung pembacaan artikel, /articles dinonaktifkan", kind)
+	}
+	if streamer, ok := articleStore.(repository.ArticleStreamer); ok {
+		mux.HandleFunc("GET /export", httpapi.NewExportHandler(streamer).HandleExport)
+	} else {
+		log.Printf("ℹ️ Sink %s tidak mendukung export, /export dinonaktifkan", kind)
+	}
+
+	port := setting(fs, "port", "PORT")
+	server := &http.Server{Addr: ":" + port, Handler: mux}
+
+	// Server dihentikan dengan rapi saat menerima Ctrl+C atau SIGTERM
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("⚠️  Gagal menghentikan server: %v", err)
+		}
+	}()
+
+	log.Printf("🚀 Menjalankan API server di http://localhost:%s", port)
+
+	// === Mulai Server ===
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("❌ Server gagal berjalan: %v", err)
+		return ExitFailure
+	}
+	log.Println("🛑 Server berhenti")
+	return ExitOK
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runStats menampilkan jumlah artikel tersimpan per sumber.
+func runStats(ctx context.Context, args []string) int {
+	fs := newFlagSet("stats", "[flags]")
+	filters := addFilterFlags(fs)
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	db := addMongoFlags(fs, true)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	filter, err := filters.filter()
+	if err != nil {
+		return usageError(fs, "%v", err)
+	}
+
+	client, database, err := db.connect(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal koneksi MongoDB: %v", err)
+		return ExitFailure
+	}
+	defer disconnect(client)
+
+	stats, err := db.articleStore(database).Stats(ctx, filter)
+	if err != nil {
+		log.Printf("❌ Gagal menghitung statistik: %v", err)
+		return ExitFailure
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(stats); err != nil {
+			log.Printf("❌ %v", err)
+			return ExitFailure
+		}
+		return ExitOK
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tARTICLES\tFETCH FAILED\tHTTP\tBROWSER\tFIRST PUBLISHED\tLAST PUBLISHED\tLAST SCRAPED")
+	total, failed, viaHTTP, viaBrowser := 0, 0, 0, 0
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n", s.Source, s.Total, s.FetchFailed, s.FetchedHTTP, s.FetchedBrowser,
+			formatDay(s.FirstPublished), formatDay(s.LastPublished), formatTime(s.LastScraped))
+		total += s.Total
+		failed += s.FetchFailed
+		viaHTTP += s.FetchedHTTP
+		viaBrowser += s.FetchedBrowser
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%d\t%d\t%d\t\t\t\n", total, failed, viaHTTP, viaBrowser)
+	w.Flush()
+	return ExitOK
+}
+
+func formatDay(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(dateLayout)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
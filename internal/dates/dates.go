@@ -0,0 +1,51 @@
+// Package dates berisi format tanggal query setiap sumber dan parser tanggal artikel
+// berbahasa Indonesia. Semua waktu hasil parse dinormalisasi ke UTC untuk disimpan.
+package dates
+
+import "time"
+
+// Jakarta adalah zona waktu Asia/Jakarta (WIB). Jika database zona waktu tidak tersedia,
+// dipakai zona tetap UTC+7 (Jakarta tidak memakai DST).
+var Jakarta = loadJakarta()
+
+func loadJakarta() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+		return loc
+	}
+	return time.FixedZone("WIB", 7*60*60)
+}
+
+// Layout query tanggal untuk setiap sumber.
+const (
+	DetikQueryLayout    = "02/01/2006"
+	Liputan6QueryLayout = "02/01/2006"
+	KompasQueryLayout   = "2006-01-02"
+)
+
+// DetikQuery memformat tanggal untuk parameter fromdatex/todatex detik, contoh "12/01/2015".
+func DetikQuery(t time.Time) string {
+	return t.Format(DetikQueryLayout)
+}
+
+// Liputan6Query memformat tanggal untuk parameter from_date/to_date liputan6, contoh "12/01/2015".
+func Liputan6Query(t time.Time) string {
+	return t.Format(Liputan6QueryLayout)
+}
+
+// KompasQuery memformat tanggal untuk parameter start_date/end_date kompas, contoh "2015-01-12".
+func KompasQuery(t time.Time) string {
+	return t.Format(KompasQueryLayout)
+}
+
+// InJakarta mengembalikan t dalam zona Asia/Jakarta.
+func InJakarta(t time.Time) time.Time {
+	return t.In(Jakarta)
+}
+
+// UTC mengembalikan t dalam UTC; waktu nol tetap nol.
+func UTC(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.UTC()
+}
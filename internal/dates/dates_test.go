@@ -0,0 +1,47 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryFormat(t *testing.T) {
+	day := time.Date(2015, time.January, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		format func(time.Time) string
+		want   string
+	}{
+		{"detik", DetikQuery, "03/01/2015"},
+		{"liputan6", Liputan6Query, "03/01/2015"},
+		{"kompas", KompasQuery, "2015-01-03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format(day); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	utc := time.Date(2015, time.January, 12, 20, 0, 0, 0, time.UTC)
+
+	local := InJakarta(utc)
+	if got := local.Format("2006-01-02 15:04"); got != "2015-01-13 03:00" {
+		t.Errorf("InJakarta = %s, want 2015-01-13 03:00", got)
+	}
+	if _, offset := local.Zone(); offset != 7*60*60 {
+		t.Errorf("Jakarta offset = %d, want %d", offset, 7*60*60)
+	}
+
+	if got := UTC(local); !got.Equal(utc) || got.Location() != time.UTC {
+		t.Errorf("UTC(%v) = %v, want %v", local, got, utc)
+	}
+	if got := UTC(time.Time{}); !got.IsZero() {
+		t.Errorf("UTC(zero) = %v, want zero time", got)
+	}
+}
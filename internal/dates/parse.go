@@ -0,0 +1,141 @@
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnrecognized dikembalikan jika format tanggal tidak dikenali.
+var ErrUnrecognized = errors.New("unrecognized date format")
+
+// zones adalah zona waktu Indonesia yang sering ditulis di akhir tanggal artikel.
+var zones = map[string]*time.Location{
+	"wib":  Jakarta,
+	"wita": time.FixedZone("WITA", 8*60*60),
+	"wit":  time.FixedZone("WIT", 9*60*60),
+}
+
+// dayNames adalah nama hari yang sering mengawali tanggal, contoh "Senin, 12 Jan 2015".
+var dayNames = []string{"senin", "selasa", "rabu", "kamis", "jumat", "jum'at", "sabtu", "minggu", "ahad"}
+
+// months memetakan nama bulan Indonesia (lengkap dan singkatan) ke time.Month.
+// Singkatan Inggris ikut dikenali karena beberapa halaman mencampur keduanya.
+var months = map[string]time.Month{
+	"januari": time.January, "jan": time.January,
+	"februari": time.February, "pebruari": time.February, "feb": time.February, "peb": time.February,
+	"maret": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"mei": time.May, "may": time.May,
+	"juni": time.June, "jun": time.June,
+	"juli": time.July, "jul": time.July,
+	"agustus": time.August, "agu": time.August, "agt": time.August, "ags": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"oktober": time.October, "okt": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November, "nop": time.November,
+	"desember": time.December, "des": time.December, "dec": time.December,
+}
+
+// numericLayouts adalah format angka yang dipakai meta tag dan JSON-LD.
+// Tanggal tanpa zona waktu dibaca dalam zona yang ditemukan (default WIB).
+var numericLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-01-02",
+	"02/01/2006, 15:04",
+	"02/01/2006 15:04",
+	"02/01/2006",
+}
+
+// textualDate cocok dengan "12 Januari 2015", "12 Jan 2015 14:03", "12 Jan. 2015, 14.03"
+// atau "12 Mei 2015 | pukul 09:15".
+var textualDate = regexp.MustCompile(`^(\d{1,2})\s+([a-z']+)\.?\s+(\d{4})(?:\s*[,|]?\s*(?:pukul\s+)?(\d{1,2})[:.](\d{2})(?:[:.](\d{2}))?)?$`)
+
+// Parse membaca tanggal artikel dalam format angka maupun teks berbahasa Indonesia,
+// contoh "Senin, 12 Jan 2015 14:03 WIB", "12 Januari 2015, 14:03", "2015/01/12 14:03:00"
+// atau "2015-01-12T14:03:00+07:00". Tanggal tanpa zona waktu dianggap WIB.
+// Hasil selalu dalam UTC.
+func Parse(value string) (time.Time, error) {
+	s := strings.Join(strings.Fields(value), " ")
+	if s == "" {
+		return time.Time{}, fmt.Errorf("%w: empty value", ErrUnrecognized)
+	}
+
+	loc := Jakarta
+	if i := strings.LastIndexByte(s, ' '); i > 0 {
+		if zone, ok := zones[strings.ToLower(s[i+1:])]; ok {
+			loc = zone
+			s = strings.TrimSpace(s[:i])
+		}
+	}
+
+	for _, layout := range numericLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	if t, ok := parseTextual(s, loc); ok {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnrecognized, value)
+}
+
+// ParseOrZero seperti Parse tetapi mengembalikan waktu nol jika format tidak dikenali,
+// cocok untuk metadata opsional.
+func ParseOrZero(value string) time.Time {
+	t, err := Parse(value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func parseTextual(s string, loc *time.Location) (time.Time, bool) {
+	s = strings.ToLower(s)
+	for _, day := range dayNames {
+		if rest, ok := strings.CutPrefix(s, day); ok && (rest == "" || rest[0] == ',' || rest[0] == ' ') {
+			s = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+			break
+		}
+	}
+
+	m := textualDate.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	month, ok := months[m[2]]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	day, _ := strconv.Atoi(m[1])
+	year, _ := strconv.Atoi(m[3])
+	var hour, minute, second int
+	if m[4] != "" {
+		hour, _ = strconv.Atoi(m[4])
+		minute, _ = strconv.Atoi(m[5])
+		if m[6] != "" {
+			second, _ = strconv.Atoi(m[6])
+		}
+	}
+	if day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+
+	t := time.Date(year, month, day, hour, minute, second, 0, loc)
+	// time.Date menormalkan tanggal tidak valid (31 Februari -> 3 Maret); tolak yang seperti itu
+	if t.Day() != day || t.Month() != month {
+		return time.Time{}, false
+	}
+	return t, true
+}
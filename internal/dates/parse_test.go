@@ -0,0 +1,113 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// 14:03 WIB pada 12 Januari 2015 sama dengan 07:03 UTC
+	want := time.Date(2015, time.January, 12, 7, 3, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		// detik: meta publishdate/updatedate dan teks tanggal di halaman
+		{"detik meta", "2015/01/12 14:03:00", want},
+		{"detik page", "Senin, 12 Jan 2015 14:03 WIB", want},
+		{"detik page without day name", "12 Jan 2015 14:03 WIB", want},
+
+		// kompas: meta content_PublishedDate dan teks read__time
+		{"kompas meta", "2015-01-12 14:03:00", want},
+		{"kompas read time", "12/01/2015, 14:03 WIB", want},
+		{"kompas read time without zone", "12/01/2015, 14:03", want},
+
+		// liputan6: meta article:published_time dan teks tanggal di halaman
+		{"liputan6 meta", "2015-01-12T14:03:00+07:00", want},
+		{"liputan6 meta without zone", "2015-01-12 14:03:00", want},
+		{"liputan6 page", "12 Jan 2015, 14:03 WIB", want},
+
+		// JSON-LD / OpenGraph
+		{"rfc3339 utc", "2015-01-12T07:03:00Z", want},
+		{"iso compact offset", "2015-01-12T14:03:00+0700", want},
+		{"iso without zone", "2015-01-12T14:03:00", want},
+		{"date only", "2015-01-12", time.Date(2015, time.January, 11, 17, 0, 0, 0, time.UTC)},
+
+		// teks berbahasa Indonesia
+		{"full month with comma", "12 Januari 2015, 14:03", want},
+		{"day name full month", "Senin, 12 Januari 2015 14:03 WIB", want},
+		{"dotted time", "12 Januari 2015 14.03", want},
+		{"seconds", "12 Januari 2015 14:03:00", want},
+		{"pukul", "12 Januari 2015 pukul 14:03 WIB", want},
+		{"pipe separator", "Senin, 12 Jan 2015 | 14:03 WIB", want},
+		{"lowercase", "senin, 12 januari 2015 14:03 wib", want},
+		{"extra spaces", "  Senin,  12   Jan 2015   14:03  WIB ", want},
+		{"abbreviation with dot", "12 Jan. 2015, 14:03", want},
+		{"date without time", "12 Januari 2015", time.Date(2015, time.January, 11, 17, 0, 0, 0, time.UTC)},
+		{"single digit day", "Kamis, 5 Mar 2015 08:00 WIB", time.Date(2015, time.March, 5, 1, 0, 0, 0, time.UTC)},
+		{"jumat apostrophe", "Jum'at, 16 Jan 2015 09:30 WIB", time.Date(2015, time.January, 16, 2, 30, 0, 0, time.UTC)},
+
+		// singkatan bulan
+		{"mei", "13 Mei 2015 09:15", time.Date(2015, time.May, 13, 2, 15, 0, 0, time.UTC)},
+		{"agu", "17 Agu 2015 10:00", time.Date(2015, time.August, 17, 3, 0, 0, 0, time.UTC)},
+		{"agt", "17 Agt 2015 10:00", time.Date(2015, time.August, 17, 3, 0, 0, 0, time.UTC)},
+		{"ags", "17 Ags 2015 10:00", time.Date(2015, time.August, 17, 3, 0, 0, 0, time.UTC)},
+		{"okt", "1 Okt 2015 10:00", time.Date(2015, time.October, 1, 3, 0, 0, 0, time.UTC)},
+		{"nop", "2 Nop 2015 10:00", time.Date(2015, time.November, 2, 3, 0, 0, 0, time.UTC)},
+		{"des", "31 Des 2015 23:30", time.Date(2015, time.December, 31, 16, 30, 0, 0, time.UTC)},
+		{"pebruari", "28 Pebruari 2015 10:00", time.Date(2015, time.February, 28, 3, 0, 0, 0, time.UTC)},
+
+		// zona waktu Indonesia
+		{"wita", "12 Jan 2015 15:03 WITA", want},
+		{"wit", "12 Jan 2015 16:03 WIT", want},
+		{"numeric wita", "2015/01/12 15:03:00 WITA", want},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("Parse(%q) location = %v, want UTC", tt.value, got.Location())
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"blank", "   "},
+		{"unknown month", "12 Foo 2015 14:03"},
+		{"invalid day", "31 Februari 2015"},
+		{"invalid hour", "12 Jan 2015 25:00"},
+		{"text", "baru saja"},
+		{"relative", "2 jam yang lalu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if !errors.Is(err, ErrUnrecognized) {
+				t.Fatalf("Parse(%q) error = %v, want ErrUnrecognized", tt.value, err)
+			}
+			if !got.IsZero() {
+				t.Errorf("Parse(%q) = %v, want zero time", tt.value, got)
+			}
+			if z := ParseOrZero(tt.value); !z.IsZero() {
+				t.Errorf("ParseOrZero(%q) = %v, want zero time", tt.value, z)
+			}
+		})
+	}
+}
@@ -1,8 +1,29 @@
 package domain
 
+import "time"
+
+// Cara konten artikel diambil, dicatat di Article.FetchPath.
+const (
+	FetchPathHTTP    = "http"
+	FetchPathBrowser = "browser"
+)
+
 type Article struct {
-	Title   string
-	URL     string
-	Summary string
-	Content string
+	ID           string    `bson:"-" json:"id,omitempty"`
+	Title        string    `bson:"title" json:"title"`
+	URL          string    `bson:"url" json:"url"`
+	CanonicalURL string    `bson:"canonical_url" json:"canonical_url"`
+	Summary      string    `bson:"summary" json:"summary"`
+	Content      string    `bson:"content" json:"content"`
+	PublishedAt  time.Time `bson:"published_at" json:"published_at"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
+	Authors      []string  `bson:"authors" json:"authors"`
+	Section      string    `bson:"section" json:"section"`
+	Tags         []string  `bson:"tags" json:"tags"`
+	Source       string    `bson:"source" json:"source"`
+	Language     string    `bson:"language" json:"language"`
+	ImageURLs    []string  `bson:"image_urls" json:"image_urls"`
+	ScrapedAt    time.Time `bson:"scraped_at" json:"scraped_at"`
+	FetchError   string    `bson:"fetch_error" json:"fetch_error,omitempty"`
+	FetchPath    string    `bson:"fetch_path" json:"fetch_path,omitempty"`
 }
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// UnitStatus adalah status satu unit backfill.
+type UnitStatus string
+
+const (
+	UnitPending UnitStatus = "pending"
+	UnitDone    UnitStatus = "done"
+	UnitFailed  UnitStatus = "failed"
+)
+
+// BackfillUnit adalah satu unit kerja backfill: satu sumber, satu query, satu hari.
+// Statusnya disimpan sebagai checkpoint agar backfill bisa dilanjutkan setelah berhenti.
+type BackfillUnit struct {
+	Source    string     `bson:"source" json:"source"`
+	Query     string     `bson:"query" json:"query"`
+	Day       time.Time  `bson:"day" json:"day"`
+	Status    UnitStatus `bson:"status" json:"status"`
+	Attempts  int        `bson:"attempts" json:"attempts"`
+	Found     int        `bson:"found" json:"found"`
+	Error     string     `bson:"error" json:"error,omitempty"`
+	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// Key adalah kunci unik unit, contoh "kompas|ekonomi jokowi|2015-01-12".
+func (u BackfillUnit) Key() string {
+	return u.Source + "|" + u.Query + "|" + u.Day.Format("2006-01-02")
+}
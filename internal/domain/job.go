@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// JobStatus adalah status sebuah scrape job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// Finished bernilai true jika job sudah berhenti dan tidak akan berjalan lagi.
+func (s JobStatus) Finished() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCanceled
+}
+
+// JobProgress mencatat kemajuan scraping sebuah job.
+type JobProgress struct {
+	PagesFetched    int `bson:"pages_fetched" json:"pages_fetched"`
+	ArticlesFound   int `bson:"articles_found" json:"articles_found"`
+	ArticlesFetched int `bson:"articles_fetched" json:"articles_fetched"`
+	ArticlesFailed  int `bson:"articles_failed" json:"articles_failed"`
+}
+
+// JobCounts mencatat hasil penyimpanan artikel sebuah job.
+type JobCounts struct {
+	Inserted  int `bson:"inserted" json:"inserted"`
+	Updated   int `bson:"updated" json:"updated"`
+	Unchanged int `bson:"unchanged" json:"unchanged"`
+}
+
+// Job adalah scrape yang dijalankan di latar belakang dan disimpan agar statusnya
+// bertahan saat server dimulai ulang.
+type Job struct {
+	ID         string      `bson:"_id" json:"id"`
+	Source     string      `bson:"source" json:"source"`
+	Query      string      `bson:"query" json:"query"`
+	StartDate  time.Time   `bson:"start_date" json:"start_date"`
+	EndDate    time.Time   `bson:"end_date" json:"end_date"`
+	Status     JobStatus   `bson:"status" json:"status"`
+	Progress   JobProgress `bson:"progress" json:"progress"`
+	Counts     JobCounts   `bson:"counts" json:"counts"`
+	Errors     []string    `bson:"errors" json:"errors"`
+	CreatedAt  time.Time   `bson:"created_at" json:"created_at"`
+	StartedAt  time.Time   `bson:"started_at" json:"started_at"`
+	FinishedAt time.Time   `bson:"finished_at" json:"finished_at"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CanonicalizeURL menormalkan URL artikel agar bisa dipakai sebagai kunci unik:
+// skema https, host huruf kecil, tanpa fragment, parameter utm_* dan garis miring di akhir path.
+func CanonicalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = "https"
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			if strings.HasPrefix(strings.ToLower(key), "utm_") {
+				query.Del(key)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = ""
+	}
+
+	return u.String()
+}
@@ -0,0 +1,194 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"the_scrapper/internal/dates"
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// articleFields adalah nama field artikel (tag json) yang boleh dipakai di parameter fields
+var articleFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(domain.Article{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" && name != "id" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// ArticleHandler melayani endpoint baca /articles
+type ArticleHandler struct {
+	reader repository.ArticleReader
+}
+
+// NewArticleHandler membuat handler artikel baru
+func NewArticleHandler(reader repository.ArticleReader) *ArticleHandler {
+	return &ArticleHandler{reader: reader}
+}
+
+// HandleList menangani GET /articles dengan filter, cursor, urutan dan projection
+func (h *ArticleHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	q, err := parseArticleQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.reader.Find(r.Context(), q)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Gagal membaca artikel: %v", err)
+		http.Error(w, "Failed to query articles", http.StatusInternalServerError)
+		return
+	}
+
+	if len(q.Fields) == 0 {
+		writeJSONResponse(w, http.StatusOK, page)
+		return
+	}
+
+	projected, err := projectArticles(page.Articles, q.Fields)
+	if err != nil {
+		log.Printf("❌ Gagal memproyeksikan artikel: %v", err)
+		http.Error(w, "Failed to query articles", http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"articles":    projected,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// HandleGet menangani GET /articles/{id}
+func (h *ArticleHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	article, err := h.reader.FindByID(r.Context(), r.PathValue("id"))
+	if errors.Is(err, repository.ErrNotFound) {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Gagal membaca artikel: %v", err)
+		http.Error(w, "Failed to read article", http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, article)
+}
+
+// parseArticleFilter membaca parameter source, from, to, q dan author.
+// Tanggal memakai format YYYY-MM-DD dan `to` bersifat inklusif.
+func parseArticleFilter(values url.Values) (repository.ArticleFilter, error) {
+	var f repository.ArticleFilter
+
+	for _, source := range values["source"] {
+		for _, s := range strings.Split(source, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				f.Sources = append(f.Sources, s)
+			}
+		}
+	}
+
+	if from := values.Get("from"); from != "" {
+		t, err := time.ParseInLocation("2006-01-02", from, dates.Jakarta)
+		if err != nil {
+			return f, errors.New("Invalid from format. Use YYYY-MM-DD")
+		}
+		f.From = t
+	}
+	if to := values.Get("to"); to != "" {
+		t, err := time.ParseInLocation("2006-01-02", to, dates.Jakarta)
+		if err != nil {
+			return f, errors.New("Invalid to format. Use YYYY-MM-DD")
+		}
+		f.To = t.AddDate(0, 0, 1)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return f, errors.New("Invalid date range: 'to' must not be before 'from'")
+	}
+
+	f.Keyword = strings.TrimSpace(values.Get("q"))
+	f.Author = strings.TrimSpace(values.Get("author"))
+	return f, nil
+}
+
+// parseArticleQuery membaca filter serta cursor, limit, sort (published_at atau
+// -published_at) dan fields (dipisah koma).
+func parseArticleQuery(values url.Values) (repository.ArticleQuery, error) {
+	var q repository.ArticleQuery
+
+	filter, err := parseArticleFilter(values)
+	if err != nil {
+		return q, err
+	}
+	q.ArticleFilter = filter
+	q.Cursor = values.Get("cursor")
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return q, errors.New("Invalid limit. Must be a positive integer")
+		}
+		q.Limit = n
+	}
+
+	switch values.Get("sort") {
+	case "", "-published_at":
+	case "published_at":
+		q.Ascending = true
+	default:
+		return q, errors.New("Invalid sort. Use 'published_at' or '-published_at'")
+	}
+
+	if fields := values.Get("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" || field == "id" {
+				continue
+			}
+			if !articleFields[field] {
+				return q, fmt.Errorf("Unknown field %q", field)
+			}
+			q.Fields = append(q.Fields, field)
+		}
+	}
+
+	return q, nil
+}
+
+// projectArticles hanya menyertakan id dan field yang diminta pada setiap artikel
+func projectArticles(articles []domain.Article, fields []string) ([]map[string]json.RawMessage, error) {
+	projected := make([]map[string]json.RawMessage, 0, len(articles))
+	for _, article := range articles {
+		raw, err := json.Marshal(article)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, err
+		}
+
+		item := map[string]json.RawMessage{"id": all["id"]}
+		for _, field := range fields {
+			item[field] = all[field]
+		}
+		projected = append(projected, item)
+	}
+	return projected, nil
+}
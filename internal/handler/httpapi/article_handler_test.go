@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/dates"
+)
+
+func TestParseArticleFilterDays(t *testing.T) {
+	f, err := parseArticleFilter(url.Values{"from": {"2020-01-01"}, "to": {"2020-01-01"}})
+	if err != nil {
+		t.Fatalf("parseArticleFilter() error = %v", err)
+	}
+
+	// Artikel 00:30 WIB tanggal 1 sudah 31 Desember di UTC, tetap masuk hari 1 Januari
+	tests := []struct {
+		name      string
+		published time.Time
+		want      bool
+	}{
+		{"start of the WIB day", time.Date(2020, 1, 1, 0, 30, 0, 0, dates.Jakarta), true},
+		{"end of the WIB day", time.Date(2020, 1, 1, 23, 30, 0, 0, dates.Jakarta), true},
+		{"previous WIB day", time.Date(2019, 12, 31, 23, 30, 0, 0, dates.Jakarta), false},
+		{"next WIB day in UTC", time.Date(2020, 1, 1, 18, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := !tt.published.Before(f.From) && tt.published.Before(f.To)
+			if got != tt.want {
+				t.Errorf("%v in [%v, %v) = %v, want %v", tt.published, f.From, f.To, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"the_scrapper/internal/adapter/export"
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// ExportHandler melayani GET /export: artikel tersimpan sebagai file JSONL, CSV atau Parquet
+type ExportHandler struct {
+	streamer repository.ArticleStreamer
+}
+
+// NewExportHandler membuat handler export baru
+func NewExportHandler(streamer repository.ArticleStreamer) *ExportHandler {
+	return &ExportHandler{streamer: streamer}
+}
+
+// HandleExport menangani GET /export dengan filter yang sama seperti GET /articles,
+// ditambah format (jsonl, csv, parquet) dan gzip. Artikel dialirkan langsung dari cursor
+// MongoDB ke response.
+func (h *ExportHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+
+	filter, err := parseArticleFilter(values)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format, err := export.ParseFormat(values.Get("format"))
+	if err != nil {
+		http.Error(w, "Invalid format. Use jsonl, csv or parquet", http.StatusBadRequest)
+		return
+	}
+	gzipped := false
+	if v := values.Get("gzip"); v != "" {
+		if gzipped, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "Invalid gzip. Use true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Writer dibuat saat artikel pertama tiba, agar error query masih bisa dijawab dengan 500
+	var out export.Writer
+	open := func() error {
+		contentType := format.ContentType()
+		if gzipped && format != export.Parquet {
+			contentType = "application/gzip"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"articles%s\"", format.Extension(gzipped)))
+
+		var err error
+		out, err = export.NewWriter(w, format, gzipped)
+		return err
+	}
+
+	n := 0
+	err = h.streamer.Stream(r.Context(), filter, func(article domain.Article) error {
+		if out == nil {
+			if err := open(); err != nil {
+				return err
+			}
+		}
+		n++
+		return out.Write(article)
+	})
+	if err != nil {
+		log.Printf("❌ Export gagal setelah %d artikel: %v", n, err)
+		if out == nil {
+			http.Error(w, "Failed to export articles", http.StatusInternalServerError)
+			return
+		}
+		// Response sudah berjalan; koneksi diputus agar klien tahu file terpotong
+		panic(http.ErrAbortHandler)
+	}
+
+	if out == nil {
+		if err := open(); err != nil {
+			log.Printf("❌ Export gagal: %v", err)
+			http.Error(w, "Failed to export articles", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := out.Close(); err != nil {
+		log.Printf("❌ Gagal menutup export: %v", err)
+		return
+	}
+	log.Printf("📦 %d artikel diekspor (%s)", n, format)
+}
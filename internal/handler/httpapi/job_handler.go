@@ -0,0 +1,161 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"the_scrapper/internal/usecase"
+)
+
+// sseKeepAlive adalah jeda komentar keep-alive pada aliran Server-Sent Events
+const sseKeepAlive = 15 * time.Second
+
+// JobHandler melayani endpoint /jobs untuk scrape asinkron
+type JobHandler struct {
+	service *usecase.JobService
+}
+
+// NewJobHandler membuat handler job baru
+func NewJobHandler(service *usecase.JobService) *JobHandler {
+	return &JobHandler{service: service}
+}
+
+// HandleCreate menangani POST /jobs: memasukkan scrape ke antrean dan langsung mengembalikan ID job
+func (h *JobHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	var req ScrapeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	startDate, endDate, err := req.dateRange()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Job hanya mendukung satu sumber; gunakan POST /scrape untuk beberapa sumber
+	var source string
+	switch names := req.sourceNames(); {
+	case len(names) > 1 || (len(names) == 1 && names[0] == usecase.AllSources):
+		http.Error(w, "Jobs support a single source", http.StatusBadRequest)
+		return
+	case len(names) == 1:
+		source = names[0]
+	}
+
+	job, err := h.service.Submit(r.Context(), source, req.Query, startDate, endDate)
+	switch {
+	case errors.Is(err, usecase.ErrUnknownSource):
+		http.Error(w, "Invalid source. Must be 'detik', 'kompas', or 'liputan6'", http.StatusBadRequest)
+		return
+	case errors.Is(err, usecase.ErrSourceUnavailable):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	case errors.Is(err, usecase.ErrEmptyQuery):
+		http.Error(w, "Query is required", http.StatusBadRequest)
+		return
+	case errors.Is(err, usecase.ErrInvalidDateRange):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		log.Printf("❌ Gagal membuat job: %v", err)
+		http.Error(w, "Failed to create job", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("📥 Job %s masuk antrean: Source=%s, Query=%s", job.ID, job.Source, job.Query)
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSONResponse(w, http.StatusAccepted, job)
+}
+
+// HandleGet menangani GET /jobs/{id}: status, progress, jumlah artikel dan error
+func (h *JobHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.Get(r.Context(), r.PathValue("id"))
+	if errors.Is(err, usecase.ErrJobNotFound) {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Gagal membaca job: %v", err)
+		http.Error(w, "Failed to read job", http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, job)
+}
+
+// HandleCancel menangani DELETE /jobs/{id}: membatalkan job yang belum selesai
+func (h *JobHandler) HandleCancel(w http.ResponseWriter, r *http.Request) {
+	job, err := h.service.Cancel(r.Context(), r.PathValue("id"))
+	switch {
+	case errors.Is(err, usecase.ErrJobNotFound):
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	case errors.Is(err, usecase.ErrJobFinished):
+		writeJSONResponse(w, http.StatusConflict, job)
+		return
+	case err != nil:
+		log.Printf("❌ Gagal membatalkan job: %v", err)
+		http.Error(w, "Failed to cancel job", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("🛑 Job %s dibatalkan", job.ID)
+	writeJSONResponse(w, http.StatusAccepted, job)
+}
+
+// HandleEvents menangani GET /jobs/{id}/events: mengalirkan progress job sebagai Server-Sent Events
+func (h *JobHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe, err := h.service.Subscribe(r.Context(), r.PathValue("id"))
+	if errors.Is(err, usecase.ErrJobNotFound) {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Gagal subscribe event job: %v", err)
+		http.Error(w, "Failed to subscribe to job events", http.StatusInternalServerError)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Komentar keep-alive agar proxy tidak menutup koneksi saat job lama tanpa event
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Printf("⚠️  Gagal encode event: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Stage, data)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -3,15 +3,15 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-
 	"the_scrapper/internal/adapter/detik"
+	"the_scrapper/internal/adapter/fetch"
 	"the_scrapper/internal/adapter/httpclient"
 	"the_scrapper/internal/adapter/kompas"
 	"the_scrapper/internal/adapter/liputan6"
@@ -20,37 +20,72 @@ import (
 	"the_scrapper/internal/usecase"
 )
 
-// ScrapeRequest mendefinisikan body JSON untuk request API
+// ScrapeRequest mendefinisikan body JSON untuk request API.
+// Gunakan "source" untuk satu sumber atau "sources" untuk beberapa ("all" untuk semua).
 type ScrapeRequest struct {
-	Source    string `json:"source"`
-	Query     string `json:"query"`
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
+	Source    string   `json:"source"`
+	Sources   []string `json:"sources"`
+	Query     string   `json:"query"`
+	StartDate string   `json:"start_date"`
+	EndDate   string   `json:"end_date"`
+}
+
+// sourceNames menggabungkan field source dan sources
+func (req ScrapeRequest) sourceNames() []string {
+	names := append([]string(nil), req.Sources...)
+	if req.Source != "" {
+		names = append(names, req.Source)
+	}
+	return names
+}
+
+// dateRange mem-parse start_date dan end_date dengan format YYYY-MM-DD
+func (req ScrapeRequest) dateRange() (time.Time, time.Time, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid start_date format. Use YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid end_date format. Use YYYY-MM-DD")
+	}
+	return startDate, endDate, nil
 }
 
 // ScrapeHandler mengelola dependensi untuk handler API
 type ScrapeHandler struct {
-	db             *mongo.Database
+	store          repository.ArticleStore
 	scraperFactory map[string]repository.Scraper
 }
 
-// NewScrapeHandler membuat handler baru dan pabrik scraper
-func NewScrapeHandler(db *mongo.Database) *ScrapeHandler {
-	httpClient := httpclient.NewHTTPClient()
-
-	// Pabrik ini memetakan nama source ke implementasi scraper-nya
-	factory := map[string]repository.Scraper{
+// NewScraperFactory memetakan nama source ke implementasi scraper-nya. kompasOpts
+// diteruskan ke scraper kompas, misal untuk mengatur browser.
+func NewScraperFactory(httpClient *http.Client, kompasOpts ...kompas.Option) map[string]repository.Scraper {
+	return map[string]repository.Scraper{
 		"detik":    detik.NewDetikScraper(httpClient),
-		"kompas":   kompas.NewKompasScraper(httpClient),
+		"kompas":   kompas.NewKompasScraper(httpClient, kompasOpts...),
 		"liputan6": liputan6.NewLiputan6Scraper(httpClient),
 	}
+}
 
+// NewScrapeHandler membuat handler baru dengan pabrik scraper yang diberikan
+func NewScrapeHandler(store repository.ArticleStore, scraperFactory map[string]repository.Scraper) *ScrapeHandler {
 	return &ScrapeHandler{
-		db:             db,
-		scraperFactory: factory,
+		store:          store,
+		scraperFactory: scraperFactory,
 	}
 }
 
+// SourceSummary adalah ringkasan hasil scraping satu sumber pada response /scrape
+type SourceSummary struct {
+	Found         int    `json:"found"`
+	FailedContent int    `json:"failed_content"`
+	Inserted      int    `json:"inserted"`
+	Updated       int    `json:"updated"`
+	Unchanged     int    `json:"unchanged"`
+	Error         string `json:"error,omitempty"`
+}
+
 // HandleScrape adalah method handler utama untuk endpoint /scrape
 func (h *ScrapeHandler) HandleScrape(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -64,22 +99,22 @@ func (h *ScrapeHandler) HandleScrape(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 1. Validasi Source
-	scraper, ok := h.scraperFactory[req.Source]
-	if !ok {
-		http.Error(w, "Invalid source. Must be 'detik', 'kompas', or 'liputan6'", http.StatusBadRequest)
+	// 1. Validasi Source (satu "source" atau beberapa "sources", "all" untuk semua)
+	service := usecase.NewMultiSearchService(h.scraperFactory)
+	sources, err := service.ResolveSources(req.sourceNames())
+	if errors.Is(err, usecase.ErrSourceUnavailable) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
 		return
 	}
-
-	// 2. Validasi Tanggal
-	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
-		http.Error(w, "Invalid start_date format. Use YYYY-MM-DD", http.StatusBadRequest)
+		http.Error(w, "Invalid source. Must be 'detik', 'kompas', 'liputan6' or 'all'", http.StatusBadRequest)
 		return
 	}
-	endDate, err := time.Parse("2006-01-02", req.EndDate)
+
+	// 2. Validasi Tanggal
+	startDate, endDate, err := req.dateRange()
 	if err != nil {
-		http.Error(w, "Invalid end_date format. Use YYYY-MM-DD", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -89,78 +124,112 @@ func (h *ScrapeHandler) HandleScrape(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// --- PERBAIKAN ---
-	// Dapatkan nama koleksi secara dinamis berdasarkan sumber (source)
-	// Ini akan membuat koleksi seperti "detik_articles" dan "kompas_articles"
-	// alih-alih menyimpan semuanya di satu tempat.
-	collectionName := fmt.Sprintf("%s_articles", req.Source)
-	collection := h.db.Collection(collectionName)
-	// --- AKHIR PERBAIKAN ---
-
-	// 4. Eksekusi Usecase
-	service := usecase.NewSearchService(scraper)
-
 	// Beri timeout 5 menit untuk setiap request scraping
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
 	defer cancel()
 
-	log.Printf("🚀 Memulai scraping: Source=%s, Query=%s, Range=%s to %s, Collection=%s",
-		req.Source, req.Query, req.StartDate, req.EndDate, collectionName)
+	log.Printf("🚀 Memulai scraping: Sources=%s, Query=%s, Range=%s to %s",
+		strings.Join(sources, ","), req.Query, req.StartDate, req.EndDate)
+
+	// 4. Eksekusi Usecase: semua sumber berjalan bersamaan, kegagalan satu sumber tidak
+	// menggagalkan sumber lain. Rentang tanggal dicari per jendela DefaultShardDays hari,
+	// dan jendela yang mencapai batas hasil sumber dipecah lagi.
+	results := service.Execute(ctx, sources, req.Query, startDate, endDate)
+
+	summaries := make(map[string]SourceSummary, len(results))
+	articles := []domain.Article{}
+	var total repository.SaveResult
+	var failed, partial, blocked int
+	var lastErr error
+
+	for _, res := range results {
+		summary := SourceSummary{Found: len(res.Articles), FailedContent: fetch.Failed(res.Articles)}
+
+		// Sumber yang gagal sebagian tetap menyimpan artikel dari jendela yang berhasil
+		if res.Err != nil {
+			if errors.Is(res.Err, httpclient.ErrDisallowedByRobots) {
+				log.Printf("🚫 Scraping %s ditolak robots.txt: %v", res.Source, res.Err)
+			} else {
+				log.Printf("❌ Gagal scraping %s: %v", res.Source, res.Err)
+			}
+			summary.Error = res.Err.Error()
+			if len(res.Articles) == 0 {
+				failed++
+				lastErr = res.Err
+				if errors.Is(res.Err, httpclient.ErrDisallowedByRobots) {
+					blocked++
+				}
+				summaries[res.Source] = summary
+				continue
+			}
+		}
 
-	// API akan scrape seluruh rentang tanggal sekaligus (bukan per hari)
-	articles, err := service.Execute(ctx, req.Query, startDate, endDate)
-	if err != nil {
-		log.Printf("❌ Gagal scraping: %v", err)
-		http.Error(w, fmt.Sprintf("Scraping failed: %v", err), http.StatusInternalServerError)
-		return
+		if len(res.Articles) == 0 {
+			log.Printf("ℹ️ Tidak ada artikel %s ditemukan untuk query: %s", res.Source, req.Query)
+			summaries[res.Source] = summary
+			continue
+		}
+
+		log.Printf("✅ %d artikel %s ditemukan (%d gagal diambil kontennya), menyimpan ke MongoDB...",
+			summary.Found, res.Source, summary.FailedContent)
+
+		// 5. Simpan ke DB (upsert berdasarkan canonical URL)
+		result, err := h.store.Save(ctx, res.Source, res.Articles)
+		if err != nil {
+			failed++
+			lastErr = err
+			log.Printf("❌ Gagal menyimpan artikel %s: %v", res.Source, err)
+			summary.Error = fmt.Sprintf("failed to save articles: %v", err)
+			summaries[res.Source] = summary
+			continue
+		}
+
+		log.Printf("💾 Artikel %s berhasil disimpan: %d baru, %d diperbarui, %d tidak berubah.",
+			res.Source, result.Inserted, result.Updated, result.Unchanged)
+		summary.Inserted, summary.Updated, summary.Unchanged = result.Inserted, result.Updated, result.Unchanged
+		summaries[res.Source] = summary
+		if res.Err != nil {
+			partial++
+		}
+
+		total.Inserted += result.Inserted
+		total.Updated += result.Updated
+		total.Unchanged += result.Unchanged
+		total.Invalid += result.Invalid
+		articles = append(articles, res.Articles...)
 	}
 
-	if len(articles) == 0 {
-		log.Printf("ℹ️ Tidak ada artikel ditemukan untuk query: %s", req.Query)
-		writeJSONResponse(w, http.StatusOK, map[string]interface{}{
-			"message":  "Scraping successful, 0 articles found.",
-			"articles": []domain.Article{},
-		})
+	// Hanya jika semua sumber gagal, request dianggap gagal
+	if failed == len(results) {
+		if errors.Is(lastErr, usecase.ErrInvalidDateRange) {
+			http.Error(w, "Invalid date range: end_date must not be before start_date", http.StatusBadRequest)
+			return
+		}
+		if blocked == len(results) {
+			http.Error(w, fmt.Sprintf("Scraping blocked by robots.txt: %v", lastErr), http.StatusForbidden)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Scraping failed: %v", lastErr), http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("✅ %d artikel ditemukan, menyimpan ke MongoDB...", len(articles))
-
-	// 5. Simpan ke DB
-	if err := saveArticles(ctx, collection, articles); err != nil {
-		log.Printf("❌ Gagal menyimpan artikel: %v", err)
-		http.Error(w, "Failed to save articles to DB", http.StatusInternalServerError)
-		return
+	message := fmt.Sprintf("Scraping successful, %d articles saved.", len(articles))
+	if failed > 0 || partial > 0 {
+		message = fmt.Sprintf("Scraping partially successful, %d articles saved, %d of %d sources failed, %d incomplete.",
+			len(articles), failed, len(results), partial)
 	}
 
-	log.Printf("💾 Artikel berhasil disimpan.")
 	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
-		"message":  fmt.Sprintf("Scraping successful, %d articles saved.", len(articles)),
-		"articles": articles,
+		"message":   message,
+		"inserted":  total.Inserted,
+		"updated":   total.Updated,
+		"unchanged": total.Unchanged,
+		"invalid":   total.Invalid,
+		"sources":   summaries,
+		"articles":  articles,
 	})
 }
 
-// saveArticles menyimpan daftar artikel ke MongoDB (dipindahkan dari main.go lama)
-func saveArticles(ctx context.Context, collection *mongo.Collection, articles []domain.Article) error {
-	docs := make([]interface{}, len(articles))
-	for i, article := range articles {
-		docs[i] = article
-	}
-
-	opts := options.InsertMany().SetOrdered(false)
-	_, err := collection.InsertMany(ctx, docs, opts)
-	if err != nil {
-		// Ini menangani error jika ada duplikat (bukan masalah besar)
-		// Tapi jika error lain, kita kembalikan
-		if mongo.IsDuplicateKeyError(err) {
-			log.Println("ℹ️  Beberapa artikel duplikat dilewati.")
-			return nil
-		}
-		return fmt.Errorf("insert failed: %w", err)
-	}
-	return nil
-}
-
 // writeJSONResponse adalah helper untuk mengirim balasan JSON
 func writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
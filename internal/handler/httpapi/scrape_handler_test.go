@@ -0,0 +1,54 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/adapter/filesink"
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+type fakeScraper struct{}
+
+func (fakeScraper) Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
+	return []domain.Article{{
+		Title:       "Banjir Jakarta",
+		URL:         "https://news.detik.com/berita/d-1/banjir",
+		Content:     "Isi artikel",
+		PublishedAt: from,
+		Source:      "detik",
+	}}, nil
+}
+
+func TestHandleScrape(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"valid request", `{"source":"detik","query":"banjir","start_date":"2020-01-01","end_date":"2020-01-02"}`, http.StatusOK},
+		{"end before start", `{"source":"detik","query":"banjir","start_date":"2020-01-10","end_date":"2020-01-01"}`, http.StatusBadRequest},
+		{"invalid date", `{"source":"detik","query":"banjir","start_date":"01-01-2020","end_date":"2020-01-01"}`, http.StatusBadRequest},
+		{"unknown source", `{"source":"tempo","query":"banjir","start_date":"2020-01-01","end_date":"2020-01-01"}`, http.StatusBadRequest},
+		{"missing query", `{"source":"detik","start_date":"2020-01-01","end_date":"2020-01-01"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewScrapeHandler(filesink.NewDirStore(t.TempDir()), map[string]repository.Scraper{"detik": fakeScraper{}})
+
+			req := httptest.NewRequest(http.MethodPost, "/scrape", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.HandleScrape(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d; body %q", rec.Code, tt.status, rec.Body.String())
+			}
+		})
+	}
+}
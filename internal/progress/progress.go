@@ -0,0 +1,66 @@
+package progress
+
+import (
+	"context"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+// Stage adalah tahap scraping yang dilaporkan.
+type Stage string
+
+const (
+	StagePageFetched    Stage = "page_fetched"
+	StageArticleQueued  Stage = "article_queued"
+	StageContentFetched Stage = "content_fetched"
+	StageContentFailed  Stage = "content_failed"
+	StageBatchSaved     Stage = "batch_saved"
+	StageDone           Stage = "done"
+
+	// StageLimitReached dilaporkan sebelum konten diambil jika pencarian berhenti karena
+	// batas halaman atau hasil sumber, artinya sebagian hasil mungkin terlewat.
+	StageLimitReached Stage = "limit_reached"
+)
+
+// Event adalah satu laporan kemajuan scraping. Field yang relevan bergantung pada Stage.
+type Event struct {
+	Stage   Stage           `json:"stage"`
+	Source  string          `json:"source,omitempty"`
+	Page    int             `json:"page,omitempty"`
+	Count   int             `json:"count,omitempty"`
+	URL     string          `json:"url,omitempty"`
+	Article *domain.Article `json:"article,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Status  string          `json:"status,omitempty"`
+	Time    time.Time       `json:"time"`
+}
+
+// Reporter menerima event kemajuan. Reporter harus aman dipanggil dari banyak goroutine.
+type Reporter func(Event)
+
+type reporterKey struct{}
+
+// WithReporter memasang reporter pada context. Reporter yang sudah ada tetap dipanggil.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	if parent, ok := ctx.Value(reporterKey{}).(Reporter); ok {
+		child := r
+		r = func(e Event) {
+			parent(e)
+			child(e)
+		}
+	}
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// Report mengirim event ke reporter di context, jika ada.
+func Report(ctx context.Context, e Event) {
+	r, ok := ctx.Value(reporterKey{}).(Reporter)
+	if !ok {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	r(e)
+}
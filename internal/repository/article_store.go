@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+// SaveResult merangkum hasil penyimpanan satu batch artikel.
+type SaveResult struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+
+	// Invalid adalah artikel tanpa URL yang tidak bisa dijadikan kunci sehingga tidak disimpan.
+	Invalid int `json:"invalid,omitempty"`
+}
+
+// ArticleStore menyimpan artikel per sumber. Artikel dengan canonical URL yang sama
+// diperbarui, bukan diduplikasi.
+type ArticleStore interface {
+	Save(ctx context.Context, source string, articles []domain.Article) (SaveResult, error)
+}
+
+// ArticleFilter membatasi artikel yang dibaca. Field kosong berarti tanpa batasan;
+// Sources kosong berarti semua sumber. FetchFailed hanya memilih artikel yang kontennya
+// gagal diambil.
+type ArticleFilter struct {
+	Sources     []string
+	From        time.Time
+	To          time.Time
+	Keyword     string
+	Author      string
+	FetchFailed bool
+}
+
+// ArticleQuery adalah satu halaman query artikel, diurutkan berdasarkan published_at.
+// Cursor diambil dari ArticlePage.NextCursor halaman sebelumnya. Fields kosong berarti
+// semua field.
+type ArticleQuery struct {
+	ArticleFilter
+	Cursor    string
+	Limit     int
+	Ascending bool
+	Fields    []string
+}
+
+// ArticlePage adalah hasil ArticleQuery. NextCursor kosong berarti tidak ada halaman lagi.
+type ArticlePage struct {
+	Articles   []domain.Article `json:"articles"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+// ArticleReader membaca artikel yang sudah tersimpan. FindByID mengembalikan ErrNotFound
+// jika artikel tidak ada, dan ErrInvalidCursor dikembalikan untuk cursor yang rusak.
+type ArticleReader interface {
+	Find(ctx context.Context, q ArticleQuery) (ArticlePage, error)
+	FindByID(ctx context.Context, id string) (*domain.Article, error)
+}
+
+// ArticleStreamer membaca semua artikel yang cocok dengan filter satu per satu, diurutkan
+// berdasarkan published_at, tanpa menahan seluruh hasil di memori. Error dari fn
+// menghentikan pembacaan dan dikembalikan.
+type ArticleStreamer interface {
+	Stream(ctx context.Context, filter ArticleFilter, fn func(domain.Article) error) error
+}
+
+// SourceStats adalah ringkasan artikel tersimpan untuk satu sumber.
+type SourceStats struct {
+	Source         string    `json:"source"`
+	Total          int       `json:"total"`
+	FetchFailed    int       `json:"fetch_failed"`
+	FetchedHTTP    int       `json:"fetched_http"`
+	FetchedBrowser int       `json:"fetched_browser"`
+	FirstPublished time.Time `json:"first_published"`
+	LastPublished  time.Time `json:"last_published"`
+	LastScraped    time.Time `json:"last_scraped"`
+}
+
+// ArticleStatsReader menghitung ringkasan artikel tersimpan per sumber.
+type ArticleStatsReader interface {
+	Stats(ctx context.Context, filter ArticleFilter) ([]SourceStats, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"the_scrapper/internal/domain"
+)
+
+// CheckpointStore menyimpan status unit backfill. Save menimpa unit dengan Key yang sama.
+type CheckpointStore interface {
+	Load(ctx context.Context, source, query string) ([]domain.BackfillUnit, error)
+	Save(ctx context.Context, unit domain.BackfillUnit) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"the_scrapper/internal/domain"
+)
+
+// JobStore menyimpan scrape job. Get mengembalikan ErrNotFound jika job tidak ada.
+type JobStore interface {
+	Create(ctx context.Context, job *domain.Job) error
+	Update(ctx context.Context, job *domain.Job) error
+	Get(ctx context.Context, id string) (*domain.Job, error)
+	ListByStatus(ctx context.Context, statuses ...domain.JobStatus) ([]domain.Job, error)
+}
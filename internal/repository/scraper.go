@@ -10,3 +10,8 @@ import (
 type Scraper interface {
 	Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error)
 }
+
+// ArticleFetcher mengambil ulang konten dan metadata satu artikel secara in-place.
+type ArticleFetcher interface {
+	FetchArticle(ctx context.Context, article *domain.Article) error
+}
@@ -0,0 +1,41 @@
+// Package testutil berisi helper bersama untuk test adapter scraper.
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Route memilih nama file di direktori testdata paket yang sedang dites untuk sebuah
+// request. String kosong berarti route sudah menulis response sendiri, misal 404 atau 503.
+type Route func(w http.ResponseWriter, r *http.Request) string
+
+// NewFixtureServer melayani fixture HTML yang dipilih route. Setiap kemunculan host di
+// fixture diganti dengan alamat server, sehingga tautan artikel di halaman pencarian
+// mengarah ke server yang sama. Server ditutup saat test selesai.
+func NewFixtureServer(t *testing.T, host string, route Route) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := route(w, r)
+		if name == "" {
+			return
+		}
+
+		raw, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Errorf("read fixture %s: %v", name, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(strings.ReplaceAll(string(raw), host, srv.URL)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// DefaultUnitTimeout adalah batas waktu scraping satu unit (satu sumber, satu hari).
+const DefaultUnitTimeout = 2 * time.Minute
+
+// BackfillRequest adalah rentang backfill. Setiap kombinasi sumber dan hari menjadi satu unit.
+// Jika Resume bernilai true, unit yang sudah selesai dilewati dan unit yang gagal diulang.
+type BackfillRequest struct {
+	Sources []string
+	Query   string
+	From    time.Time
+	To      time.Time
+	Resume  bool
+}
+
+// BackfillSummary merangkum hasil backfill. FailedUnits berisi unit yang masih gagal.
+type BackfillSummary struct {
+	Done        int
+	Failed      int
+	Skipped     int
+	Counts      repository.SaveResult
+	FailedUnits []domain.BackfillUnit
+}
+
+// BackfillService menjalankan backfill per hari dan mencatat checkpoint setiap unit.
+type BackfillService struct {
+	scrapers    map[string]repository.Scraper
+	articles    repository.ArticleStore
+	checkpoints repository.CheckpointStore
+	unitTimeout time.Duration
+}
+
+// BackfillOption mengatur perilaku BackfillService.
+type BackfillOption func(*BackfillService)
+
+// WithUnitTimeout mengatur batas waktu scraping satu unit.
+func WithUnitTimeout(d time.Duration) BackfillOption {
+	return func(s *BackfillService) {
+		if d > 0 {
+			s.unitTimeout = d
+		}
+	}
+}
+
+func NewBackfillService(scrapers map[string]repository.Scraper, articles repository.ArticleStore, checkpoints repository.CheckpointStore, opts ...BackfillOption) *BackfillService {
+	s := &BackfillService{
+		scrapers:    scrapers,
+		articles:    articles,
+		checkpoints: checkpoints,
+		unitTimeout: DefaultUnitTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run menjalankan semua unit secara berurutan. Semua unit yang akan dikerjakan dicatat
+// sebagai pending lebih dulu, sehingga jika proses berhenti di tengah jalan checkpoint
+// menunjukkan unit mana yang belum selesai. Error hanya dikembalikan jika backfill tidak
+// bisa dilanjutkan (context dibatalkan atau checkpoint gagal disimpan); unit yang gagal
+// dicatat di summary.
+func (s *BackfillService) Run(ctx context.Context, req BackfillRequest) (BackfillSummary, error) {
+	var summary BackfillSummary
+
+	if req.Query == "" {
+		return summary, ErrEmptyQuery
+	}
+	if req.To.Before(req.From) {
+		return summary, ErrInvalidDateRange
+	}
+	for _, source := range req.Sources {
+		if _, ok := s.scrapers[source]; !ok {
+			return summary, fmt.Errorf("%w: %q", ErrUnknownSource, source)
+		}
+	}
+
+	for _, source := range req.Sources {
+		units, err := s.plan(ctx, source, req, &summary)
+		if err != nil {
+			return summary, err
+		}
+
+		for _, unit := range units {
+			if err := s.runUnit(ctx, &unit, &summary); err != nil {
+				return summary, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// plan menyusun unit sumber untuk setiap hari dan mencatatnya sebagai pending.
+func (s *BackfillService) plan(ctx context.Context, source string, req BackfillRequest, summary *BackfillSummary) ([]domain.BackfillUnit, error) {
+	existing, err := s.checkpoints.Load(ctx, source, req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint %s: %w", source, err)
+	}
+	previous := make(map[string]domain.BackfillUnit, len(existing))
+	for _, unit := range existing {
+		previous[unit.Key()] = unit
+	}
+
+	var units []domain.BackfillUnit
+	for day := req.From; !day.After(req.To); day = day.AddDate(0, 0, 1) {
+		unit := domain.BackfillUnit{Source: source, Query: req.Query, Day: day}
+		if prev, ok := previous[unit.Key()]; ok {
+			if req.Resume && prev.Status == domain.UnitDone {
+				summary.Skipped++
+				continue
+			}
+			unit.Attempts = prev.Attempts
+		}
+
+		unit.Status = domain.UnitPending
+		unit.UpdatedAt = time.Now().UTC()
+		if err := s.checkpoints.Save(ctx, unit); err != nil {
+			return nil, fmt.Errorf("save checkpoint: %w", err)
+		}
+		units = append(units, unit)
+	}
+	return units, nil
+}
+
+// runUnit men-scrape dan menyimpan artikel satu unit lalu mencatat hasilnya.
+// Jika ctx dibatalkan, unit dibiarkan pending.
+func (s *BackfillService) runUnit(ctx context.Context, unit *domain.BackfillUnit, summary *BackfillSummary) error {
+	day := unit.Day.Format("02-01-2006")
+	log.Printf("📅 [%s] %s: memulai scraping...", day, unit.Source)
+
+	unitCtx, cancel := context.WithTimeout(ctx, s.unitTimeout)
+	defer cancel()
+
+	service := NewSearchService(s.scrapers[unit.Source], WithShardDays(ShardDay))
+	articles, err := service.Execute(unitCtx, unit.Query, unit.Day, unit.Day)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	unit.Attempts++
+	unit.Found = len(articles)
+	unit.Error = ""
+
+	if err != nil {
+		log.Printf("❌ [%s] %s: gagal scraping: %v", day, unit.Source, err)
+		unit.Status, unit.Error = domain.UnitFailed, err.Error()
+	} else {
+		unit.Status = domain.UnitDone
+	}
+
+	// Artikel yang sudah ditemukan tetap disimpan walaupun unit gagal sebagian
+	if len(articles) == 0 && err == nil {
+		log.Printf("ℹ️ [%s] %s: tidak ada artikel ditemukan", day, unit.Source)
+	}
+	if len(articles) > 0 {
+		result, saveErr := s.articles.Save(ctx, unit.Source, articles)
+		if saveErr != nil {
+			log.Printf("❌ [%s] %s: gagal menyimpan artikel: %v", day, unit.Source, saveErr)
+			unit.Status, unit.Error = domain.UnitFailed, fmt.Sprintf("save articles: %v", saveErr)
+		} else {
+			log.Printf("💾 [%s] %s: %d artikel disimpan: %d baru, %d diperbarui, %d tidak berubah.",
+				day, unit.Source, len(articles), result.Inserted, result.Updated, result.Unchanged)
+			summary.Counts.Inserted += result.Inserted
+			summary.Counts.Updated += result.Updated
+			summary.Counts.Unchanged += result.Unchanged
+			summary.Counts.Invalid += result.Invalid
+		}
+	}
+
+	if unit.Status == domain.UnitDone {
+		summary.Done++
+	} else {
+		summary.Failed++
+		summary.FailedUnits = append(summary.FailedUnits, *unit)
+	}
+
+	unit.UpdatedAt = time.Now().UTC()
+	if err := s.checkpoints.Save(ctx, *unit); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
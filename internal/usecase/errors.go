@@ -3,5 +3,10 @@ package usecase
 import "errors"
 
 var (
-	ErrInvalidDateRange = errors.New("invalid date range: 'to' date must be after 'from' date")
+	ErrInvalidDateRange  = errors.New("invalid date range: 'to' date must be after 'from' date")
+	ErrUnknownSource     = errors.New("unknown source")
+	ErrSourceUnavailable = errors.New("source unavailable")
+	ErrEmptyQuery        = errors.New("query is required")
+	ErrJobNotFound       = errors.New("job not found")
+	ErrJobFinished       = errors.New("job already finished")
 )
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"log"
+	"sync"
+
+	"the_scrapper/internal/progress"
+)
+
+// jobEventBuffer adalah kapasitas antrean event per subscriber. Subscriber yang terlalu
+// lambat kehilangan event, bukan menahan job.
+const jobEventBuffer = 256
+
+// jobEvents meneruskan event progress job yang sedang aktif ke para subscriber.
+type jobEvents struct {
+	mu     sync.Mutex
+	active map[string][]chan progress.Event
+}
+
+func newJobEvents() *jobEvents {
+	return &jobEvents{active: make(map[string][]chan progress.Event)}
+}
+
+// open menandai job aktif sehingga bisa di-subscribe.
+func (b *jobEvents) open(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.active[id]; !ok {
+		b.active[id] = nil
+	}
+}
+
+// subscribe mengembalikan false jika job tidak sedang aktif di proses ini.
+func (b *jobEvents) subscribe(id string) (<-chan progress.Event, func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs, ok := b.active[id]
+	if !ok {
+		return nil, nil, false
+	}
+	ch := make(chan progress.Event, jobEventBuffer)
+	b.active[id] = append(subs, ch)
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.active[id]
+		for i, sub := range subs {
+			if sub == ch {
+				b.active[id] = append(subs[:i], subs[i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}
+	return ch, unsubscribe, true
+}
+
+func (b *jobEvents) publish(id string, e progress.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.active[id] {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("⚠️  Event %s job %s dibuang, subscriber terlalu lambat", e.Stage, id)
+		}
+	}
+}
+
+// close mengirim event terakhir lalu menutup semua subscriber job. Jika antrean subscriber
+// penuh, event tertua dibuang agar event terakhir tetap sampai.
+func (b *jobEvents) close(id string, last progress.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.active[id] {
+		select {
+		case ch <- last:
+		default:
+			// Hanya publish dan close yang mengirim, keduanya memegang b.mu, jadi setelah
+			// satu event dibaca pasti ada tempat
+			select {
+			case dropped := <-ch:
+				log.Printf("⚠️  Event %s job %s dibuang, subscriber terlalu lambat", dropped.Stage, id)
+			default:
+			}
+			ch <- last
+		}
+		close(ch)
+	}
+	delete(b.active, id)
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"testing"
+
+	"the_scrapper/internal/progress"
+)
+
+func TestJobEventsCloseDeliversLastEventToSlowSubscriber(t *testing.T) {
+	events := newJobEvents()
+	events.open("job")
+	ch, unsubscribe, ok := events.subscribe("job")
+	if !ok {
+		t.Fatal("subscribe() = false for an open job")
+	}
+	defer unsubscribe()
+
+	// Subscriber tidak membaca apa pun sampai antreannya penuh
+	for range jobEventBuffer + 10 {
+		events.publish("job", progress.Event{Stage: progress.StagePageFetched})
+	}
+	events.close("job", progress.Event{Stage: progress.StageDone, Status: "succeeded"})
+
+	var last progress.Event
+	n := 0
+	for e := range ch {
+		last = e
+		n++
+	}
+	if last.Stage != progress.StageDone || last.Status != "succeeded" {
+		t.Errorf("last event = %+v, want the done event", last)
+	}
+	if n != jobEventBuffer {
+		t.Errorf("received %d events, want %d", n, jobEventBuffer)
+	}
+}
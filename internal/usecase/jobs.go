@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
+	"the_scrapper/internal/repository"
+)
+
+const (
+	// DefaultMaxConcurrentJobs adalah jumlah job yang boleh berjalan bersamaan.
+	DefaultMaxConcurrentJobs = 2
+
+	// jobFlushInterval adalah jeda minimal penyimpanan progress job ke store.
+	jobFlushInterval = 2 * time.Second
+
+	// jobPersistTimeout membatasi penyimpanan status akhir job, yang memakai context
+	// terpisah agar tetap tersimpan meskipun job dibatalkan.
+	jobPersistTimeout = 10 * time.Second
+)
+
+// JobService menjalankan scrape job di latar belakang dan menyimpan statusnya ke JobStore.
+type JobService struct {
+	jobs     repository.JobStore
+	articles repository.ArticleStore
+	scrapers map[string]repository.Scraper
+	slots    chan struct{}
+	events   *jobEvents
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+}
+
+func NewJobService(jobs repository.JobStore, articles repository.ArticleStore, scrapers map[string]repository.Scraper, maxConcurrent int) *JobService {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentJobs
+	}
+	return &JobService{
+		jobs:     jobs,
+		articles: articles,
+		scrapers: scrapers,
+		slots:    make(chan struct{}, maxConcurrent),
+		events:   newJobEvents(),
+		running:  make(map[string]context.CancelFunc),
+	}
+}
+
+// Submit memvalidasi dan menyimpan job baru lalu menjalankannya di latar belakang.
+func (s *JobService) Submit(ctx context.Context, source, query string, from, to time.Time) (*domain.Job, error) {
+	scraper, ok := s.scrapers[source]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSource, source)
+	}
+	if unavailable, ok := scraper.(UnavailableScraper); ok {
+		return nil, fmt.Errorf("%s: %w", source, unavailable.unavailable())
+	}
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+	if to.Before(from) {
+		return nil, ErrInvalidDateRange
+	}
+
+	job := &domain.Job{
+		ID:        newJobID(),
+		Source:    source,
+		Query:     query,
+		StartDate: from,
+		EndDate:   to,
+		Status:    domain.JobQueued,
+		Errors:    []string{},
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.jobs.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	snapshot := *job
+	s.start(job)
+	return &snapshot, nil
+}
+
+// Get mengembalikan status job terakhir yang tersimpan.
+func (s *JobService) Get(ctx context.Context, id string) (*domain.Job, error) {
+	job, err := s.jobs.Get(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrJobNotFound
+	}
+	return job, err
+}
+
+// Cancel menghentikan job yang sedang antre atau berjalan. Job yang berjalan di proses ini
+// dibatalkan lewat context-nya; job yatim dari proses sebelumnya langsung ditandai canceled.
+func (s *JobService) Cancel(ctx context.Context, id string) (*domain.Job, error) {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status.Finished() {
+		return job, ErrJobFinished
+	}
+
+	s.mu.Lock()
+	cancel, ok := s.running[id]
+	s.mu.Unlock()
+
+	if ok {
+		cancel()
+		return job, nil
+	}
+
+	job.Status = domain.JobCanceled
+	job.FinishedAt = time.Now().UTC()
+	if err := s.jobs.Update(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Subscribe mengembalikan aliran event progress sebuah job. Aliran ditutup setelah event
+// done terkirim. Untuk job yang sudah selesai (atau berjalan di proses lain) hanya event
+// done berisi status terakhir yang dikirim.
+func (s *JobService) Subscribe(ctx context.Context, id string) (<-chan progress.Event, func(), error) {
+	if ch, unsubscribe, ok := s.events.subscribe(id); ok {
+		return ch, unsubscribe, nil
+	}
+
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan progress.Event, 1)
+	ch <- doneEvent(*job)
+	close(ch)
+	return ch, func() {}, nil
+}
+
+// Resume menjalankan ulang job yang masih queued atau running di store, misalnya
+// setelah server dimulai ulang. Artikel disimpan dengan upsert sehingga aman diulang.
+func (s *JobService) Resume(ctx context.Context) (int, error) {
+	jobs, err := s.jobs.ListByStatus(ctx, domain.JobQueued, domain.JobRunning)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status == domain.JobRunning {
+			job.Errors = append(job.Errors, "interrupted by server restart, resumed")
+		}
+		job.Status = domain.JobQueued
+		job.Progress = domain.JobProgress{}
+		if err := s.jobs.Update(ctx, job); err != nil {
+			return i, err
+		}
+		s.start(job)
+	}
+	return len(jobs), nil
+}
+
+func (s *JobService) start(job *domain.Job) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.events.open(job.ID)
+
+	s.mu.Lock()
+	s.running[job.ID] = cancel
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, job.ID)
+			s.mu.Unlock()
+			cancel()
+		}()
+		s.run(ctx, newJobTracker(s.jobs, s.events, job))
+	}()
+}
+
+func (s *JobService) run(ctx context.Context, t *jobTracker) {
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		t.finish(domain.JobCanceled, nil)
+		return
+	}
+
+	job := t.snapshot()
+	scraper, ok := s.scrapers[job.Source]
+	if !ok {
+		t.finish(domain.JobFailed, fmt.Errorf("%w: %q", ErrUnknownSource, job.Source))
+		return
+	}
+
+	t.update(func(j *domain.Job) {
+		j.Status = domain.JobRunning
+		j.StartedAt = time.Now().UTC()
+	})
+	t.flush()
+
+	// finish menghentikan autoFlush; defer hanya berjaga jika run keluar tanpa finish
+	stop := t.autoFlush()
+	defer stop()
+
+	ctx = progress.WithReporter(ctx, t.report)
+	log.Printf("🚀 Job %s dimulai: Source=%s, Query=%s", job.ID, job.Source, job.Query)
+
+	// Jendela yang gagal tidak membuang artikel dari jendela lain: hasil sebagian tetap
+	// disimpan, lalu job ditandai gagal dengan daftar jendela yang gagal
+	articles, searchErr := NewSearchService(scraper).Execute(ctx, job.Query, job.StartDate, job.EndDate)
+	if ctx.Err() != nil {
+		t.finish(domain.JobCanceled, nil)
+		return
+	}
+	if searchErr != nil && len(articles) == 0 {
+		t.finish(domain.JobFailed, searchErr)
+		return
+	}
+
+	result, err := s.articles.Save(ctx, job.Source, articles)
+	t.update(func(j *domain.Job) {
+		j.Counts = domain.JobCounts{Inserted: result.Inserted, Updated: result.Updated, Unchanged: result.Unchanged}
+	})
+	if ctx.Err() != nil {
+		t.finish(domain.JobCanceled, nil)
+		return
+	}
+	if err != nil {
+		t.finish(domain.JobFailed, err)
+		return
+	}
+	progress.Report(ctx, progress.Event{Stage: progress.StageBatchSaved, Source: job.Source, Count: len(articles)})
+
+	if searchErr != nil {
+		t.finish(domain.JobFailed, searchErr)
+		return
+	}
+	t.finish(domain.JobSucceeded, nil)
+}
+
+// jobTracker menjaga salinan job yang diperbarui dari banyak goroutine dan
+// menyimpannya ke store secara berkala.
+type jobTracker struct {
+	store  repository.JobStore
+	events *jobEvents
+
+	mu  sync.Mutex
+	job domain.Job
+
+	// stopFlush menghentikan autoFlush; finish memanggilnya sebelum penyimpanan terakhir
+	stopFlush func()
+}
+
+func newJobTracker(store repository.JobStore, events *jobEvents, job *domain.Job) *jobTracker {
+	return &jobTracker{store: store, events: events, job: *job}
+}
+
+func (t *jobTracker) update(fn func(*domain.Job)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(&t.job)
+}
+
+func (t *jobTracker) snapshot() domain.Job {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job := t.job
+	job.Errors = append([]string(nil), t.job.Errors...)
+	return job
+}
+
+func (t *jobTracker) report(e progress.Event) {
+	t.update(func(j *domain.Job) {
+		switch e.Stage {
+		case progress.StagePageFetched:
+			j.Progress.PagesFetched++
+		case progress.StageArticleQueued:
+			j.Progress.ArticlesFound++
+		case progress.StageContentFetched:
+			j.Progress.ArticlesFetched++
+		case progress.StageContentFailed:
+			j.Progress.ArticlesFailed++
+		}
+	})
+	t.events.publish(t.job.ID, e)
+}
+
+func (t *jobTracker) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), jobPersistTimeout)
+	defer cancel()
+
+	job := t.snapshot()
+	if err := t.store.Update(ctx, &job); err != nil {
+		log.Printf("⚠️  Gagal menyimpan status job %s: %v", job.ID, err)
+	}
+}
+
+// autoFlush menyimpan progress setiap jobFlushInterval sampai fungsi stop atau finish
+// dipanggil. stop aman dipanggil lebih dari sekali.
+func (t *jobTracker) autoFlush() (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(jobFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.flush()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+	t.update(func(*domain.Job) { t.stopFlush = stop })
+	return stop
+}
+
+// finish menyimpan status akhir job. autoFlush dihentikan lebih dulu agar flush berkala
+// yang terlambat tidak menimpa status akhir dengan salinan lama.
+func (t *jobTracker) finish(status domain.JobStatus, err error) {
+	var stop func()
+	t.update(func(*domain.Job) { stop = t.stopFlush })
+	if stop != nil {
+		stop()
+	}
+
+	t.update(func(j *domain.Job) {
+		j.Status = status
+		j.FinishedAt = time.Now().UTC()
+		if err != nil {
+			j.Errors = append(j.Errors, err.Error())
+		}
+	})
+	t.flush()
+
+	job := t.snapshot()
+	t.events.close(job.ID, doneEvent(job))
+	log.Printf("🏁 Job %s selesai dengan status %s", job.ID, job.Status)
+}
+
+// doneEvent adalah event penutup berisi status akhir job.
+func doneEvent(job domain.Job) progress.Event {
+	e := progress.Event{
+		Stage:  progress.StageDone,
+		Source: job.Source,
+		Status: string(job.Status),
+		Count:  job.Counts.Inserted + job.Counts.Updated + job.Counts.Unchanged,
+		Time:   time.Now().UTC(),
+	}
+	if len(job.Errors) > 0 {
+		e.Error = job.Errors[len(job.Errors)-1]
+	}
+	return e
+}
+
+func newJobID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+// AllSources adalah nama pintas untuk memilih semua sumber.
+const AllSources = "all"
+
+// SourceResult adalah hasil pencarian satu sumber. Err terisi jika sumber tersebut gagal
+// seluruhnya atau sebagian; Articles tetap berisi artikel dari jendela yang berhasil.
+// Kegagalan satu sumber tidak memengaruhi sumber lain.
+type SourceResult struct {
+	Source   string
+	Articles []domain.Article
+	Err      error
+}
+
+// UnavailableScraper menggantikan scraper yang tidak bisa dipakai, misal kompas tanpa
+// browser. Sumbernya tetap dikenal tetapi dilewati oleh "all", dan memilihnya langsung
+// menghasilkan ErrSourceUnavailable.
+type UnavailableScraper struct {
+	Err error
+}
+
+func (s UnavailableScraper) Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
+	return nil, s.unavailable()
+}
+
+func (s UnavailableScraper) unavailable() error {
+	return fmt.Errorf("%w: %v", ErrSourceUnavailable, s.Err)
+}
+
+// MultiSearchService menjalankan beberapa scraper sekaligus secara bersamaan.
+type MultiSearchService struct {
+	scrapers map[string]repository.Scraper
+}
+
+func NewMultiSearchService(scrapers map[string]repository.Scraper) *MultiSearchService {
+	return &MultiSearchService{scrapers: scrapers}
+}
+
+// ResolveSources memvalidasi nama sumber, membuang duplikat, dan mengganti "all"
+// dengan semua sumber yang dikenal dan tersedia (urut abjad).
+func (s *MultiSearchService) ResolveSources(names []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var sources []string
+	for _, name := range names {
+		if name == AllSources {
+			all := make([]string, 0, len(s.scrapers))
+			for source, scraper := range s.scrapers {
+				if _, ok := scraper.(UnavailableScraper); !ok {
+					all = append(all, source)
+				}
+			}
+			sort.Strings(all)
+			for _, source := range all {
+				if !seen[source] {
+					seen[source] = true
+					sources = append(sources, source)
+				}
+			}
+			continue
+		}
+		scraper, ok := s.scrapers[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSource, name)
+		}
+		if unavailable, ok := scraper.(UnavailableScraper); ok {
+			return nil, fmt.Errorf("%s: %w", name, unavailable.unavailable())
+		}
+		if !seen[name] {
+			seen[name] = true
+			sources = append(sources, name)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%w: no source given", ErrUnknownSource)
+	}
+	return sources, nil
+}
+
+// Execute mencari di semua sumber secara bersamaan. Urutan hasil mengikuti urutan sources.
+func (s *MultiSearchService) Execute(ctx context.Context, sources []string, query string, from, to time.Time) []SourceResult {
+	results := make([]SourceResult, len(sources))
+
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		results[i].Source = source
+
+		scraper, ok := s.scrapers[source]
+		if !ok {
+			results[i].Err = fmt.Errorf("%w: %q", ErrUnknownSource, source)
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *SourceResult) {
+			defer wg.Done()
+			// Panic di satu adapter tidak boleh menjatuhkan sumber lain
+			defer func() {
+				if p := recover(); p != nil {
+					r.Err = fmt.Errorf("scraper %s panicked: %v", r.Source, p)
+				}
+			}()
+			r.Articles, r.Err = NewSearchService(scraper).Execute(ctx, query, from, to)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results
+}
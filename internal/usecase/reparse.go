@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"the_scrapper/internal/domain"
+	"the_scrapper/internal/repository"
+)
+
+const (
+	// DefaultReparseWorkers adalah jumlah artikel yang diambil ulang secara bersamaan.
+	DefaultReparseWorkers = 4
+
+	reparseBatchSize = 100
+)
+
+// ReparseSummary merangkum hasil pengambilan ulang artikel tersimpan.
+type ReparseSummary struct {
+	Processed int
+	Fetched   int
+	Failed    int
+	Skipped   int
+	Counts    repository.SaveResult
+}
+
+// ReparseService mengambil ulang konten dan metadata artikel yang sudah tersimpan,
+// misal artikel yang dulu gagal diambil atau setelah parser diperbaiki.
+type ReparseService struct {
+	reader   repository.ArticleReader
+	store    repository.ArticleStore
+	fetchers map[string]repository.ArticleFetcher
+	workers  int
+}
+
+// NewReparseService membuat ReparseService. Scraper yang tidak mengimplementasikan
+// repository.ArticleFetcher dilewati.
+func NewReparseService(reader repository.ArticleReader, store repository.ArticleStore, scrapers map[string]repository.Scraper, workers int) *ReparseService {
+	if workers <= 0 {
+		workers = DefaultReparseWorkers
+	}
+	fetchers := make(map[string]repository.ArticleFetcher)
+	for source, scraper := range scrapers {
+		if fetcher, ok := scraper.(repository.ArticleFetcher); ok {
+			fetchers[source] = fetcher
+		}
+	}
+	return &ReparseService{reader: reader, store: store, fetchers: fetchers, workers: workers}
+}
+
+// Run mengambil ulang semua artikel yang cocok dengan filter per batch dan menyimpannya.
+// Jika pengambilan gagal, data lama dipertahankan dan hanya fetch_error yang diperbarui.
+func (s *ReparseService) Run(ctx context.Context, filter repository.ArticleFilter) (ReparseSummary, error) {
+	var summary ReparseSummary
+	q := repository.ArticleQuery{ArticleFilter: filter, Limit: reparseBatchSize, Ascending: true}
+
+	for {
+		page, err := s.reader.Find(ctx, q)
+		if err != nil {
+			return summary, fmt.Errorf("read articles: %w", err)
+		}
+
+		bySource := make(map[string][]domain.Article)
+		for _, article := range page.Articles {
+			if _, ok := s.fetchers[article.Source]; !ok {
+				summary.Skipped++
+				continue
+			}
+			bySource[article.Source] = append(bySource[article.Source], article)
+		}
+
+		for source, articles := range bySource {
+			s.refetch(ctx, s.fetchers[source], articles)
+			if err := ctx.Err(); err != nil {
+				return summary, err
+			}
+
+			summary.Processed += len(articles)
+			for _, article := range articles {
+				if article.FetchError == "" {
+					summary.Fetched++
+				} else {
+					summary.Failed++
+				}
+			}
+
+			result, err := s.store.Save(ctx, source, articles)
+			if err != nil {
+				return summary, fmt.Errorf("save %s articles: %w", source, err)
+			}
+			summary.Counts.Inserted += result.Inserted
+			summary.Counts.Updated += result.Updated
+			summary.Counts.Unchanged += result.Unchanged
+			summary.Counts.Invalid += result.Invalid
+		}
+
+		log.Printf("🔄 %d artikel diproses ulang (%d berhasil, %d gagal)", summary.Processed, summary.Fetched, summary.Failed)
+
+		if page.NextCursor == "" {
+			return summary, nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+// refetch mengambil ulang artikel dengan paling banyak s.workers goroutine.
+func (s *ReparseService) refetch(ctx context.Context, fetcher repository.ArticleFetcher, articles []domain.Article) {
+	sem := make(chan struct{}, s.workers)
+	var wg sync.WaitGroup
+
+	for i := range articles {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(article *domain.Article) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			original := *article
+			clearExtracted(article)
+			if err := fetcher.FetchArticle(ctx, article); err != nil {
+				*article = original
+				article.FetchError = err.Error()
+				return
+			}
+			article.FetchError = ""
+		}(&articles[i])
+	}
+	wg.Wait()
+}
+
+// clearExtracted mengosongkan field hasil parsing halaman artikel agar diisi ulang.
+// Judul, ringkasan dan tanggal terbit dari halaman pencarian dipertahankan.
+func clearExtracted(article *domain.Article) {
+	article.Content = ""
+	article.UpdatedAt = time.Time{}
+	article.Authors = nil
+	article.Section = ""
+	article.Tags = nil
+	article.Language = ""
+	article.ImageURLs = nil
+}
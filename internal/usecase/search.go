@@ -2,28 +2,185 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
 	"time"
 
 	"the_scrapper/internal/domain"
+	"the_scrapper/internal/progress"
 	"the_scrapper/internal/repository"
 )
 
+// Ukuran jendela (dalam hari) untuk memecah rentang tanggal pencarian.
+const (
+	ShardDay  = 1
+	ShardWeek = 7
+
+	// DefaultShardDays dipakai jika WithShardDays tidak diberikan.
+	DefaultShardDays = ShardWeek
+)
+
+// errWindowLimited menghentikan pencarian satu jendela yang mencapai batas hasil sumber
+// agar jendela tersebut bisa dipecah sebelum konten artikel diambil.
+var errWindowLimited = errors.New("search window reached source result limit")
+
+// WindowError adalah kegagalan pencarian satu jendela tanggal. Execute menggabungkannya
+// dengan errors.Join, sehingga errors.Is dan errors.As tetap bisa dipakai.
+type WindowError struct {
+	From time.Time
+	To   time.Time
+	Err  error
+}
+
+func (e *WindowError) Error() string {
+	if e.From.Equal(e.To) {
+		return fmt.Sprintf("search %s: %v", e.From.Format("2006-01-02"), e.Err)
+	}
+	return fmt.Sprintf("search %s to %s: %v", e.From.Format("2006-01-02"), e.To.Format("2006-01-02"), e.Err)
+}
+
+func (e *WindowError) Unwrap() error {
+	return e.Err
+}
+
 type SearchService struct {
-	scraper repository.Scraper
+	scraper   repository.Scraper
+	shardDays int
 }
 
-func NewSearchService(scraper repository.Scraper) *SearchService {
-	return &SearchService{scraper: scraper}
+// SearchOption mengatur perilaku SearchService.
+type SearchOption func(*SearchService)
+
+// WithShardDays mengatur ukuran jendela pencarian dalam hari, misal ShardDay atau ShardWeek.
+// 0 berarti seluruh rentang dicari sekaligus (tetap dipecah jika mencapai batas hasil).
+func WithShardDays(days int) SearchOption {
+	return func(s *SearchService) {
+		if days >= 0 {
+			s.shardDays = days
+		}
+	}
 }
 
+func NewSearchService(scraper repository.Scraper, opts ...SearchOption) *SearchService {
+	s := &SearchService{scraper: scraper, shardDays: DefaultShardDays}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Execute mencari artikel dalam rentang tanggal (inklusif). Rentang dipecah menjadi jendela
+// berukuran shardDays; jendela yang mencapai batas hasil sumber dibelah dua sampai tersisa
+// satu hari. Hasil semua jendela digabung tanpa duplikat.
+//
+// Jendela yang gagal tidak menghentikan jendela lain: artikel dari jendela yang berhasil
+// tetap dikembalikan bersama error gabungan berisi *WindowError untuk setiap jendela yang
+// gagal, agar pemanggil bisa menyimpan hasil sebagian dan melaporkan hari yang gagal.
 func (s *SearchService) Execute(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
 	if to.Before(from) {
 		return nil, ErrInvalidDateRange
 	}
 
-	results, err := s.scraper.Search(ctx, query, from, to)
+	results := newArticleSet()
+
+	if s.shardDays == 0 {
+		err := s.searchWindow(ctx, query, from, to, results)
+		return results.articles, err
+	}
+
+	var errs []error
+	for start := from; !start.After(to); {
+		end := start.AddDate(0, 0, s.shardDays-1)
+		if end.After(to) {
+			end = to
+		}
+		if err := s.searchWindow(ctx, query, start, end, results); err != nil {
+			errs = append(errs, err)
+		}
+		// Setelah ctx dibatalkan, jendela berikutnya pasti gagal dengan error yang sama
+		if ctx.Err() != nil {
+			break
+		}
+		start = end.AddDate(0, 0, 1)
+	}
+	return results.articles, errors.Join(errs...)
+}
+
+// searchWindow mencari satu jendela. Jika scraper melaporkan StageLimitReached dan jendela
+// lebih dari satu hari, pencarian dihentikan lalu jendela dibelah dua. Artikel yang sudah
+// ditemukan selalu ditambahkan ke results, juga saat jendela gagal.
+func (s *SearchService) searchWindow(ctx context.Context, query string, from, to time.Time, results *articleSet) error {
+	days := int(to.Sub(from).Hours() / 24)
+	splittable := days >= 1
+
+	windowCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var limited atomic.Bool
+	windowCtx = progress.WithReporter(windowCtx, func(e progress.Event) {
+		if e.Stage != progress.StageLimitReached {
+			return
+		}
+		limited.Store(true)
+		if splittable {
+			cancel(errWindowLimited)
+		}
+	})
+
+	articles, err := s.scraper.Search(windowCtx, query, from, to)
+
+	if limited.Load() && splittable && ctx.Err() == nil {
+		mid := from.AddDate(0, 0, days/2)
+		log.Printf("✂️  Hasil %s s/d %s mencapai batas sumber, rentang dipecah",
+			from.Format("2006-01-02"), to.Format("2006-01-02"))
+		first := s.searchWindow(ctx, query, from, mid, results)
+		if ctx.Err() != nil {
+			return first
+		}
+		return errors.Join(first, s.searchWindow(ctx, query, mid.AddDate(0, 0, 1), to, results))
+	}
+
+	// Scraper bisa mengembalikan hasil sebagian bersama error, misal satu hari indeks gagal
+	results.add(articles)
 	if err != nil {
-		return nil, err
+		return &WindowError{From: from, To: to, Err: err}
+	}
+
+	if limited.Load() {
+		log.Printf("⚠️  Hasil %s mencapai batas sumber, sebagian artikel mungkin terlewat", from.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// articleSet menggabungkan artikel dari beberapa jendela berdasarkan canonical URL,
+// dengan urutan sesuai kemunculan pertama.
+type articleSet struct {
+	articles []domain.Article
+	index    map[string]int
+}
+
+func newArticleSet() *articleSet {
+	return &articleSet{articles: []domain.Article{}, index: make(map[string]int)}
+}
+
+func (s *articleSet) add(articles []domain.Article) {
+	for _, a := range articles {
+		key := a.CanonicalURL
+		if key == "" {
+			key = domain.CanonicalizeURL(a.URL)
+		}
+
+		i, ok := s.index[key]
+		if !ok {
+			s.index[key] = len(s.articles)
+			s.articles = append(s.articles, a)
+			continue
+		}
+		// Artikel yang sama di jendela lain: pakai versi yang kontennya berhasil diambil
+		if s.articles[i].FetchError != "" && a.FetchError == "" {
+			s.articles[i] = a
+		}
 	}
-	return results, nil
 }
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"the_scrapper/internal/domain"
+)
+
+// dayScraper mengembalikan satu artikel per hari, atau error untuk hari di failDays.
+type dayScraper struct {
+	failDays map[string]error
+}
+
+func (s *dayScraper) Search(ctx context.Context, query string, from, to time.Time) ([]domain.Article, error) {
+	var articles []domain.Article
+	var errs []error
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		if err, ok := s.failDays[key]; ok {
+			errs = append(errs, err)
+			continue
+		}
+		articles = append(articles, domain.Article{URL: "https://example.com/" + key, Source: "test"})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return articles, nil
+}
+
+func TestExecuteKeepsResultsOfFailedWindows(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	scraper := &dayScraper{failDays: map[string]error{"2020-01-02": errTimeout}}
+	service := NewSearchService(scraper, WithShardDays(ShardDay))
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	articles, err := service.Execute(context.Background(), "banjir", from, to)
+
+	if len(articles) != 2 {
+		t.Errorf("Execute() returned %d articles, want 2 from the successful days", len(articles))
+	}
+	if !errors.Is(err, errTimeout) {
+		t.Fatalf("Execute() error = %v, want %v", err, errTimeout)
+	}
+
+	var windowErr *WindowError
+	if !errors.As(err, &windowErr) {
+		t.Fatalf("Execute() error = %v, want a *WindowError", err)
+	}
+	if day := windowErr.From.Format("2006-01-02"); day != "2020-01-02" {
+		t.Errorf("WindowError.From = %s, want 2020-01-02", day)
+	}
+}